@@ -0,0 +1,120 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/anqiansong/github-compare/pkg/check"
+	"github.com/anqiansong/github-compare/pkg/stat"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rulesFile string
+
+	checkCmd = &cobra.Command{
+		Use:          "check",
+		Short:        checkCMDDesc,
+		Example:      "github-compare check --rules rules.yaml spf13/cobra urfave/cli",
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE:         runCheck,
+	}
+)
+
+func init() {
+	checkCmd.Flags().StringVarP(&rulesFile, flagRules, flagRulesShortHand, defaultEmptyString,
+		flagRulesDesc)
+	_ = checkCmd.MarkFlagRequired(flagRules)
+	rootCmd.AddCommand(checkCmd)
+}
+
+func runCheck(c *cobra.Command, args []string) error {
+	args, err := expandGroups(args...)
+	if err != nil {
+		return err
+	}
+
+	args = normalizeRepos(args...)
+	if err := validateGithubRepo(args...); err != nil {
+		return err
+	}
+
+	rules, err := check.LoadRules(rulesFile)
+	if err != nil {
+		return err
+	}
+
+	weights, err := stat.LoadScoreWeights(weightsFile)
+	if err != nil {
+		return err
+	}
+
+	data, err := getData(c.Context(), false, args...)
+	if err != nil {
+		return err
+	}
+
+	stat.Rate(data, weights)
+	results := check.Run(rules, data)
+	fmt.Println(renderCheckResults(results))
+
+	var failed int
+	for _, e := range results {
+		if !e.Passed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(results))
+	}
+
+	fmt.Printf("all %d checks passed\n", len(results))
+	return nil
+}
+
+func renderCheckResults(results []check.Result) string {
+	var (
+		pass = color.New(color.FgHiGreen).Sprint("✔ pass")
+		fail = color.New(color.FgHiRed).Sprint("✘ fail")
+	)
+
+	t := table.NewWriter()
+	t.SetStyle(table.StyleLight)
+	t.AppendHeader(table.Row{"repository", "rule", "actual", "result"})
+	for _, e := range results {
+		result := pass
+		if !e.Passed {
+			result = fail
+		}
+		if e.Err != nil {
+			result = fmt.Sprintf("%s: %v", fail, e.Err)
+		}
+		t.AppendRow(table.Row{e.Repo, e.Rule, e.Actual, result})
+	}
+
+	return t.Render()
+}
@@ -0,0 +1,98 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anqiansong/github-compare/pkg/stat"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fatih/color"
+)
+
+// languageBarWidth is the number of cells of the language bar.
+const languageBarWidth = 20
+
+// colorize renders the language, the archived flag and the star trends of d in
+// color.
+func colorize(d *stat.Data, r stat.Result) {
+	if len(r.Language) > 0 {
+		d.Language = lipgloss.NewStyle().Foreground(lipgloss.Color(r.LanguageColor)).
+			Render(fmt.Sprintf("%s %s", "◉", r.Language))
+	}
+	if len(r.Languages) > 0 {
+		d.LanguageBreakdown = languageBar(r.Languages) + "\n" + d.LanguageBreakdown
+	}
+	if d.Metrics.Archived {
+		d.Archived = color.New(color.FgHiRed, color.Bold).Sprint(d.Archived)
+	}
+	if r.Stargazers != nil {
+		d.LatestDayStarCount = formatStarTrend(stat.StarTrend(r.Stargazers, 24*time.Hour))
+		d.LatestWeekStarCount = formatStarTrend(stat.StarTrend(r.Stargazers, 7*24*time.Hour))
+	}
+}
+
+func formatStarTrend(stars, trend int) string {
+	var c *color.Color
+	switch {
+	case trend < 0:
+		c = color.New(color.FgHiRed)
+	case trend > 0:
+		c = color.New(color.FgHiGreen)
+	default:
+		return stat.FormatStarTrend(stars, trend)
+	}
+
+	return c.Sprint(stat.FormatStarTrend(stars, trend))
+}
+
+// languageBar renders the languages as a stacked bar in their colors, the
+// languages without color or too small to take a cell are left gray.
+func languageBar(list []stat.LanguageShare) string {
+	var (
+		b    strings.Builder
+		used int
+	)
+	for _, e := range list {
+		cells := int(e.Percent/100*languageBarWidth + 0.5)
+		if used+cells > languageBarWidth {
+			cells = languageBarWidth - used
+		}
+		if cells == 0 {
+			continue
+		}
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+		if len(e.Color) > 0 {
+			style = lipgloss.NewStyle().Foreground(lipgloss.Color(e.Color))
+		}
+		b.WriteString(style.Render(strings.Repeat("█", cells)))
+		used += cells
+	}
+	if used < languageBarWidth {
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("240")).
+			Render(strings.Repeat("█", languageBarWidth-used)))
+	}
+	return b.String()
+}
@@ -0,0 +1,220 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/anqiansong/github-compare/pkg/stat"
+	"github.com/anqiansong/github-compare/pkg/timex"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	configKeyPresets = "presets"
+	configKeyGroups  = "groups"
+	// groupPrefix marks an argument as a group of repositories in config file,
+	// e.g. @web-frameworks.
+	groupPrefix = "@"
+)
+
+type (
+	config struct {
+		// Tokens are the access tokens of github, gitlab and gitea, the
+		// flag and the environment variables take precedence.
+		Tokens  map[string]string   `mapstructure:"tokens"`
+		Style   string              `mapstructure:"style"`
+		Layout  string              `mapstructure:"layout"`
+		Windows windowsConfig       `mapstructure:"windows"`
+		Fields  []string            `mapstructure:"fields"`
+		Presets map[string][]string `mapstructure:"presets"`
+		Groups  map[string][]string `mapstructure:"groups"`
+	}
+
+	windowsConfig struct {
+		Stars     string `mapstructure:"stars"`
+		Activity  string `mapstructure:"activity"`
+		Lifecycle string `mapstructure:"lifecycle"`
+		History   string `mapstructure:"history"`
+	}
+)
+
+var (
+	// loadedConfig is the config file read before every command.
+	loadedConfig config
+	// configWindows are the windows in config file, --history overrides the
+	// history one.
+	configWindows stat.Windows
+	gitlabToken   string
+	giteaToken    string
+)
+
+func defaultConfigFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "github-compare", "config.yaml")
+}
+
+// loadConfig reads the config file, the default config file is optional while
+// the one specified by --config must exist.
+func loadConfig(file string) (config, error) {
+	var c config
+	explicit := len(file) > 0
+	if !explicit {
+		file = defaultConfigFile()
+	}
+	if len(file) == 0 {
+		return c, nil
+	}
+
+	if _, err := os.Stat(file); err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return c, nil
+		}
+		return c, err
+	}
+
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		return c, err
+	}
+	if err := v.Unmarshal(&c); err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
+
+func (c config) preset(name string) ([]string, error) {
+	// viper is case-insensitive, all the keys are lowercase
+	fields, ok := c.Presets[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("preset %q is not found in %s", name, configKeyPresets)
+	}
+	return fields, nil
+}
+
+// applyConfig reads the config file and takes its values for the flags which
+// are not set.
+func applyConfig(c *cobra.Command) error {
+	cfg, err := loadConfig(configFile)
+	if err != nil {
+		return err
+	}
+	loadedConfig = cfg
+
+	flags := c.Flags()
+	if !flags.Changed(styleJSON) && !flags.Changed(styleYAML) && !flags.Changed(styleTermUI) {
+		switch strings.ToLower(cfg.Style) {
+		case "", styleTermUI:
+		case styleJSON:
+			jsonStyle = true
+		case styleYAML:
+			yamlStyle = true
+		default:
+			return fmt.Errorf("invalid style %q in config file, expected %s, %s or %s", cfg.Style,
+				styleTermUI, styleJSON, styleYAML)
+		}
+	}
+	if !flags.Changed(flagLayout) && len(cfg.Layout) > 0 {
+		layout = cfg.Layout
+	}
+	if !flags.Changed(flagFields) && !flags.Changed(flagPreset) && len(cfg.Fields) > 0 {
+		fields = cfg.Fields
+	}
+
+	if !flags.Changed(flagToken) && len(os.Getenv("GITHUB_ACCESS_TOKEN")) == 0 {
+		githubAccessToken = cfg.Tokens["github"]
+	}
+	if len(os.Getenv("GITLAB_ACCESS_TOKEN")) == 0 {
+		gitlabToken = cfg.Tokens["gitlab"]
+	}
+	if len(os.Getenv("GITEA_ACCESS_TOKEN")) == 0 {
+		giteaToken = cfg.Tokens["gitea"]
+	}
+
+	configWindows, err = cfg.Windows.parse()
+	return err
+}
+
+func (w windowsConfig) parse() (stat.Windows, error) {
+	var ret stat.Windows
+	for _, e := range []struct {
+		name   string
+		value  string
+		window *time.Duration
+	}{
+		{name: "stars", value: w.Stars, window: &ret.Stars},
+		{name: "activity", value: w.Activity, window: &ret.Activity},
+		{name: "lifecycle", value: w.Lifecycle, window: &ret.Lifecycle},
+		{name: "history", value: w.History, window: &ret.History},
+	} {
+		if len(e.value) == 0 {
+			continue
+		}
+		d, err := timex.ParseDuration(e.value)
+		if err != nil {
+			return ret, fmt.Errorf("invalid windows.%s in config file: %w", e.name, err)
+		}
+		*e.window = d
+	}
+	return ret, nil
+}
+
+// expandGroups replaces the @group arguments with the repositories of the
+// groups, the repeated repositories are dropped.
+func expandGroups(args ...string) ([]string, error) {
+	var (
+		ret  []string
+		seen = map[string]struct{}{}
+	)
+	for _, e := range args {
+		repos := []string{e}
+		if strings.HasPrefix(e, groupPrefix) {
+			// viper is case-insensitive, all the keys are lowercase
+			group, ok := loadedConfig.Groups[strings.ToLower(strings.TrimPrefix(e, groupPrefix))]
+			if !ok {
+				return nil, fmt.Errorf("group %q is not found in %s", e, configKeyGroups)
+			}
+			repos = group
+		}
+
+		for _, repo := range repos {
+			if _, ok := seen[repo]; ok {
+				continue
+			}
+			seen[repo] = struct{}{}
+			ret = append(ret, repo)
+		}
+	}
+	return ret, nil
+}
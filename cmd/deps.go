@@ -0,0 +1,118 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/anqiansong/github-compare/pkg/deps"
+	"github.com/kevwan/mapreduce/v2"
+	"github.com/spf13/cobra"
+)
+
+var (
+	includeIndirect bool
+	includeDev      bool
+
+	depsCmd = &cobra.Command{
+		Use:     "deps",
+		Short:   depsCMDDesc,
+		Example: "github-compare deps ./go.mod --layout rows",
+		Args:    cobra.MinimumNArgs(1),
+		RunE:    runDeps,
+	}
+)
+
+func init() {
+	depsCmd.Flags().BoolVar(&includeIndirect, flagIndirect, false, flagIndirectDesc)
+	depsCmd.Flags().BoolVar(&includeDev, flagDev, false, flagDevDesc)
+	rootCmd.AddCommand(depsCmd)
+}
+
+func runDeps(c *cobra.Command, args []string) error {
+	var list []deps.Dependency
+	for _, file := range args {
+		ret, err := deps.ParseFile(file)
+		if err != nil {
+			return err
+		}
+		for _, e := range ret {
+			if e.Indirect && !includeIndirect || e.Dev && !includeDev {
+				continue
+			}
+			list = append(list, e)
+		}
+	}
+
+	repos := resolveDependencies(c.Context(), list)
+	if len(repos) == 0 {
+		return fmt.Errorf("no github hosted dependencies found in %v", args)
+	}
+	if err := validateGithubRepo(repos...); err != nil {
+		return err
+	}
+
+	// a dependency tree is usually too wide to show a column per repository
+	if !c.Flags().Changed(flagLayout) {
+		layout = layoutRows
+	}
+
+	return compareRepos(c.Context(), repos...)
+}
+
+func resolveDependencies(ctx context.Context, list []deps.Dependency) []string {
+	var (
+		resolver = deps.NewResolver()
+		repos    = make([]string, len(list))
+		errs     = make([]error, len(list))
+	)
+	resolver.Client.Transport = httpClient.Transport
+
+	mapreduce.ForEach(func(source chan<- int) {
+		for i := range list {
+			source <- i
+		}
+	}, func(i int) {
+		repos[i], errs[i] = resolver.Resolve(ctx, list[i])
+	}, mapreduce.WithWorkers(16), mapreduce.WithContext(ctx))
+
+	var (
+		ret  []string
+		seen = make(map[string]struct{})
+	)
+	for i, e := range repos {
+		if errs[i] != nil {
+			fmt.Fprintf(os.Stderr, "skip %s: %v\n", list[i].Name, errs[i])
+			continue
+		}
+		if _, ok := seen[e]; ok {
+			continue
+		}
+		seen[e] = struct{}{}
+		ret = append(ret, e)
+	}
+
+	return ret
+}
@@ -34,9 +34,11 @@ import (
 )
 
 const (
-	exportTPJSON = "json"
-	exportTPYAML = "yaml"
-	exportTPCSV  = "csv"
+	exportTPJSON     = "json"
+	exportTPYAML     = "yaml"
+	exportTPCSV      = "csv"
+	exportTPMarkdown = "markdown"
+	exportTPHTML     = "html"
 )
 
 var outputFile string
@@ -51,13 +53,25 @@ func export(data []stat.Data, tp string) error {
 		marshal, _ := yaml.Marshal(data)
 		buffer.Write(marshal)
 	case exportTPCSV:
-		t, err := createTable(data, false, true)
+		t, err := createTable(data, false, true, nil)
 		if err != nil {
 			return err
 		}
 		// solve garbled characters
 		buffer.WriteString("\xEF\xBB\xBF")
 		buffer.WriteString(t.RenderCSV())
+	case exportTPMarkdown:
+		t, err := createTable(data, false, false, highlightMarkdown)
+		if err != nil {
+			return err
+		}
+		buffer.WriteString(t.RenderMarkdown())
+	case exportTPHTML:
+		t, err := createTable(data, false, false, highlightHTML)
+		if err != nil {
+			return err
+		}
+		buffer.WriteString(htmlHighlightReplacer.Replace(t.RenderHTML()))
 	default:
 		return fmt.Errorf("invalid type %q", tp)
 	}
@@ -0,0 +1,229 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anqiansong/github-compare/pkg/stat"
+)
+
+const (
+	fieldRank           = "rank"
+	fieldScoreBreakdown = "scoreBreakdown"
+	fieldCommunity      = "community"
+)
+
+type tableRow struct {
+	name    string
+	title   string
+	field   string
+	csvOnly bool
+	format  func(stat.Data) string
+	// winner is the metric which decides the best value of the row
+	winner string
+}
+
+var (
+	tableRows = []tableRow{
+		{name: fieldRank, title: "rank", field: "rank"},
+		{name: "description", title: "description", field: "description", csvOnly: true},
+		{name: "tags", title: "tags", field: "tags", csvOnly: true},
+		{name: "monthStargazers", title: "latestMonthStargazers",
+			field: "latestMonthStargazers.data", csvOnly: true},
+		{name: "weekForks", title: "latestWeekForks", field: "latestWeekForks.data", csvOnly: true},
+		{name: "weekCommits", title: "latestWeekCommits", field: "latestWeekCommits.data",
+			csvOnly: true},
+		{name: "weekIssues", title: "latestWeekIssues", field: "latestWeekIssues.data",
+			csvOnly: true},
+		{name: "releaseTimeline", title: "releaseTimeline", field: "releaseTimeline.data",
+			csvOnly: true},
+		{name: "punchCardSun", title: "punchCard.Sun", field: "punchCard.matrix", csvOnly: true,
+			format: formatPunchCard(time.Sunday)},
+		{name: "punchCardMon", title: "punchCard.Mon", field: "punchCard.matrix", csvOnly: true,
+			format: formatPunchCard(time.Monday)},
+		{name: "punchCardTue", title: "punchCard.Tue", field: "punchCard.matrix", csvOnly: true,
+			format: formatPunchCard(time.Tuesday)},
+		{name: "punchCardWed", title: "punchCard.Wed", field: "punchCard.matrix", csvOnly: true,
+			format: formatPunchCard(time.Wednesday)},
+		{name: "punchCardThu", title: "punchCard.Thu", field: "punchCard.matrix", csvOnly: true,
+			format: formatPunchCard(time.Thursday)},
+		{name: "punchCardFri", title: "punchCard.Fri", field: "punchCard.matrix", csvOnly: true,
+			format: formatPunchCard(time.Friday)},
+		{name: "punchCardSat", title: "punchCard.Sat", field: "punchCard.matrix", csvOnly: true,
+			format: formatPunchCard(time.Saturday)},
+		{name: "archived", title: "archived", field: "archived"},
+		{name: "homepage", title: "homepage", field: "homepage"},
+		{name: "language", title: "language", field: "language"},
+		{name: "languages", title: "languages", field: "languageBreakdown"},
+		{name: "license", title: "license", field: "license"},
+		{name: "age", title: "age", field: "age", winner: stat.SortAge},
+		{name: "stars", title: "stars", field: "starCount", winner: stat.SortStars},
+		{name: "dayStars", title: "latestDayStarCount", field: "latestDayStarCount",
+			winner: stat.SortDayStars},
+		{name: "weekStars", title: "latestWeekStarCount", field: "latestWeekStarCount",
+			winner: stat.SortWeekStars},
+		{name: "monthStars", title: "latestMonthStarCount", field: "latestMonthStarCount",
+			winner: stat.SortMonthStars},
+		{name: "lowSignalStars", title: "low-signal stargazers", field: "lowSignalStars",
+			winner: stat.SortLowSignalStars},
+		{name: "starBursts", title: "star bursts", field: "starBursts",
+			winner: stat.SortStarBursts},
+		{name: "starForecast", title: "star forecast", field: "starProjection",
+			winner: stat.SortStarForecast},
+		{name: "forks", title: "forks", field: "forkCount", winner: stat.SortForks},
+		{name: "forkForecast", title: "fork forecast", field: "forkProjection",
+			winner: stat.SortForkForecast},
+		{name: "watchers", title: "watchers", field: "watcherCount", winner: stat.SortWatchers},
+		{name: "issues", title: "issues", field: "issue", winner: stat.SortOpenIssueRatio},
+		{name: "pulls", title: "pull requests", field: "pull", winner: stat.SortPulls},
+		{name: "contributors", title: "contributors", field: "contributorCount",
+			winner: stat.SortContributors},
+		{name: "releases", title: "releases", field: "releaseCount", winner: stat.SortReleases},
+		{name: "releasePeriod", title: "release circle(avg)", field: "avgReleasePeriod",
+			winner: stat.SortReleasePeriod},
+		{name: "lastRelease", title: "lastRelease", field: "latestReleaseAt",
+			winner: stat.SortLastRelease},
+		{name: "releaseInterval", title: "release interval(median)", field: "releaseInterval",
+			winner: stat.SortReleaseGap},
+		{name: "releaseTrend", title: "release trend", field: "releaseTrend"},
+		{name: "releaseCadence", title: "release cadence", field: "releaseCadence"},
+		{name: "lastStableRelease", title: "last stable release", field: "lastStableRelease",
+			winner: stat.SortStableRelease},
+		{name: "downloads", title: "downloads", field: "releaseDownloads",
+			winner: stat.SortDownloads},
+		{name: "lastPush", title: "lastCommit", field: "lastPushedAt", winner: stat.SortLastPush},
+		{name: "lastUpdate", title: "lastUpdate", field: "lastUpdatedAt",
+			winner: stat.SortLastUpdate},
+		{name: "pullMergeRatio", title: "merged pulls", field: "pullMergeRatio",
+			winner: stat.SortPullMergeRatio},
+		{name: "pullMergeTime", title: "time to merge", field: "pullMergeTime",
+			winner: stat.SortPullMergeTime},
+		{name: "pullResponse", title: "pull first response", field: "pullFirstResponse",
+			winner: stat.SortPullResponse},
+		{name: "firstTimePulls", title: "first-time contributor pulls",
+			field: "firstTimePullShare"},
+		{name: "openPullAge", title: "open pull age", field: "openPullAge"},
+		{name: "issueResponse", title: "issue first response", field: "issueFirstResponse",
+			winner: stat.SortIssueResponse},
+		{name: "issueCloseTime", title: "time to close", field: "issueCloseTime",
+			winner: stat.SortIssueCloseTime},
+		{name: "staleIssues", title: "closed as stale", field: "staleIssueRatio",
+			winner: stat.SortStaleIssues},
+		{name: "issueBacklog", title: "issue backlog", field: "issueBacklog",
+			winner: stat.SortIssueBacklog},
+		{name: "topContributors", title: "top contributors", field: "topContributors",
+			winner: stat.SortTopContributor},
+		{name: "busFactor", title: "bus factor", field: "busFactor",
+			winner: stat.SortBusFactor},
+		{name: "maintainers", title: "active maintainers", field: "activeMaintainers",
+			winner: stat.SortMaintainers},
+		{name: "organizations", title: "organizations", field: "organizations",
+			winner: stat.SortOrganizations},
+		{name: "weekendCommits", title: "weekend commits", field: "weekendCommits"},
+		{name: "officeHourCommits", title: "office hour commits", field: "officeHourCommits"},
+		{name: "timeZones", title: "author time zones", field: "commitTimeZones"},
+		{name: "notableEvents", title: "notable events", field: "notableEvents"},
+		{name: "repositoryKind", title: "repository kind", field: "repositoryKind"},
+		{name: "communityHealth", title: "community health", field: "communityHealth",
+			winner: stat.SortCommunity},
+		{name: fieldCommunity, title: "community checklist", field: "community",
+			format: formatCommunity},
+		{name: "funding", title: "funding", field: "funding"},
+		{name: "score", title: "score", field: "score.total", winner: stat.SortScore},
+		{name: fieldScoreBreakdown, title: "score breakdown", field: "score.breakdown",
+			format: formatScoreBreakdown},
+	}
+
+	// selectedRows holds the rows chosen by --fields or --preset, nil means
+	// the default rows.
+	selectedRows []tableRow
+	// excludedRows holds the names of rows removed by --exclude.
+	excludedRows = map[string]struct{}{}
+)
+
+func getTableRows(exportCSV bool) []tableRow {
+	rows := selectedRows
+	if rows == nil {
+		for _, r := range tableRows {
+			if r.csvOnly && !exportCSV {
+				continue
+			}
+			rows = append(rows, r)
+		}
+	}
+
+	var ret []tableRow
+	for _, r := range rows {
+		if _, ok := excludedRows[r.name]; ok {
+			continue
+		}
+		ret = append(ret, r)
+	}
+	return ret
+}
+
+func fieldNames() []string {
+	var ret []string
+	for _, r := range tableRows {
+		ret = append(ret, r.name)
+	}
+	return ret
+}
+
+func findTableRow(name string) (tableRow, error) {
+	for _, r := range tableRows {
+		if strings.EqualFold(r.name, strings.TrimSpace(name)) {
+			return r, nil
+		}
+	}
+	return tableRow{}, fmt.Errorf("invalid field %q, expected one of: %s", name,
+		strings.Join(fieldNames(), ", "))
+}
+
+func selectRows(fields, exclude []string) error {
+	for _, e := range exclude {
+		r, err := findTableRow(e)
+		if err != nil {
+			return err
+		}
+		excludedRows[r.name] = struct{}{}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	selectedRows = []tableRow{}
+	for _, e := range fields {
+		r, err := findTableRow(e)
+		if err != nil {
+			return err
+		}
+		selectedRows = append(selectedRows, r)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anqiansong/github-compare/pkg/stat"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// highlighter decorates the best value of a row.
+type highlighter func(string) string
+
+const (
+	htmlHighlightStart = "\x02"
+	htmlHighlightEnd   = "\x03"
+)
+
+// htmlHighlightReplacer turns the placeholders into tags after go-pretty has
+// escaped the cell contents.
+var htmlHighlightReplacer = strings.NewReplacer(htmlHighlightStart, "<strong>",
+	htmlHighlightEnd, "</strong>")
+
+func highlightTerm(s string) string {
+	return color.New(color.Bold, color.FgHiGreen).Sprint(s)
+}
+
+func highlightMarkdown(s string) string {
+	return fmt.Sprintf("**%s**", s)
+}
+
+func highlightHTML(s string) string {
+	return htmlHighlightStart + s + htmlHighlightEnd
+}
+
+func highlightWinner(row table.Row, winner string, mark highlighter, list []stat.Data) table.Row {
+	if len(winner) == 0 || mark == nil {
+		return row
+	}
+
+	for _, idx := range stat.Best(list, winner) {
+		// the first column is the title
+		row[idx+1] = mark(fmt.Sprintf("%v", row[idx+1]))
+	}
+
+	return row
+}
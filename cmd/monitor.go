@@ -0,0 +1,164 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/anqiansong/github-compare/pkg/monitor"
+	"github.com/anqiansong/github-compare/pkg/stat"
+	"github.com/anqiansong/github-compare/pkg/timex"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// webhookTimeout limits a single post of alerts.
+const webhookTimeout = 10 * time.Second
+
+var (
+	stateFile       string
+	webhookURL      string
+	webhookPayload  string
+	monitorInterval string
+
+	monitorCmd = &cobra.Command{
+		Use:   "monitor",
+		Short: monitorCMDDesc,
+		Example: "github-compare monitor --rules monitor.yaml --webhook https://hooks.slack.com/... " +
+			"--payload slack gin-gonic/gin labstack/echo",
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE:         runMonitor,
+	}
+)
+
+func init() {
+	flags := monitorCmd.Flags()
+	flags.StringVarP(&rulesFile, flagRules, flagRulesShortHand, defaultEmptyString, flagRulesDesc)
+	flags.StringVar(&stateFile, flagState, "github-compare-monitor.json", flagStateDesc)
+	flags.StringVar(&webhookURL, flagWebhook, defaultEmptyString, flagWebhookDesc)
+	flags.StringVar(&webhookPayload, flagPayload, monitor.PayloadGeneric, flagPayloadDesc)
+	flags.StringVar(&monitorInterval, flagInterval, defaultEmptyString, flagIntervalDesc)
+	_ = monitorCmd.MarkFlagRequired(flagRules)
+	rootCmd.AddCommand(monitorCmd)
+}
+
+func runMonitor(c *cobra.Command, args []string) error {
+	args, err := expandGroups(args...)
+	if err != nil {
+		return err
+	}
+
+	args = normalizeRepos(args...)
+	if err := validateGithubRepo(args...); err != nil {
+		return err
+	}
+	if err := monitor.CheckPayload(webhookPayload); err != nil {
+		return err
+	}
+
+	var interval time.Duration
+	if len(monitorInterval) > 0 {
+		d, err := timex.ParseDuration(monitorInterval)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid --%s: %q", flagInterval, monitorInterval)
+		}
+		interval = d
+	}
+
+	rules, err := monitor.LoadRules(rulesFile)
+	if err != nil {
+		return err
+	}
+
+	weights, err := stat.LoadScoreWeights(weightsFile)
+	if err != nil {
+		return err
+	}
+
+	webhook := monitor.Webhook{URL: webhookURL, Payload: webhookPayload,
+		Client: &http.Client{Timeout: webhookTimeout}}
+	if interval == 0 {
+		return monitorOnce(c.Context(), rules, weights, webhook, args...)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		// a failed run is retried in the next interval
+		if err := monitorOnce(c.Context(), rules, weights, webhook, args...); err != nil {
+			fmt.Fprintf(os.Stderr, "monitor: %v\n", err)
+		}
+
+		select {
+		case <-c.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// monitorOnce fetches the repositories, posts the alerts and saves the state,
+// the state is not saved if the alerts fail to post so that they are posted
+// again in the next run.
+func monitorOnce(ctx context.Context, rules []monitor.Rule, weights stat.ScoreWeights,
+	webhook monitor.Webhook, repos ...string) error {
+	state, err := monitor.LoadState(stateFile)
+	if err != nil {
+		return err
+	}
+
+	data, err := getData(ctx, false, repos...)
+	if err != nil {
+		return err
+	}
+
+	stat.Rate(data, weights)
+	alerts, next := monitor.Evaluate(rules, data, state, time.Now())
+	fmt.Println(renderAlerts(alerts))
+	if len(alerts) > 0 && len(webhook.URL) > 0 {
+		if err := webhook.Notify(ctx, alerts); err != nil {
+			return err
+		}
+	}
+
+	return next.Save(stateFile)
+}
+
+func renderAlerts(alerts []monitor.Alert) string {
+	if len(alerts) == 0 {
+		return fmt.Sprintf("%s no alerts", time.Now().Format(time.RFC3339))
+	}
+
+	t := table.NewWriter()
+	t.SetStyle(table.StyleLight)
+	t.AppendHeader(table.Row{"repository", "rule", "actual", "at"})
+	for _, e := range alerts {
+		t.AppendRow(table.Row{e.Repo, e.Rule, e.Actual, e.At.Format(time.RFC3339)})
+	}
+	return t.Render()
+}
@@ -0,0 +1,184 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/anqiansong/github-compare/pkg/compare"
+	"github.com/anqiansong/github-compare/pkg/stat"
+	"github.com/anqiansong/github-compare/pkg/timex"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+var (
+	overlapWindow string
+
+	overlapCmd = &cobra.Command{
+		Use:          "overlap",
+		Short:        overlapCMDDesc,
+		Example:      "github-compare overlap --window 90d spf13/cobra urfave/cli -f overlap.csv",
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE:         runOverlap,
+	}
+)
+
+func init() {
+	overlapCmd.Flags().StringVar(&overlapWindow, flagWindow, "90d", flagWindowDesc)
+	rootCmd.AddCommand(overlapCmd)
+}
+
+func runOverlap(c *cobra.Command, args []string) error {
+	args, err := expandGroups(args...)
+	if err != nil {
+		return err
+	}
+	if len(args) < 2 {
+		return fmt.Errorf("overlap requires at least 2 repositories")
+	}
+
+	args = normalizeRepos(args...)
+	if err := validateGithubRepo(args...); err != nil {
+		return err
+	}
+	for _, e := range args {
+		if stat.IsExternal(e) {
+			return fmt.Errorf("overlap supports the repositories on GitHub only: %s", e)
+		}
+	}
+
+	window, err := timex.ParseDuration(overlapWindow)
+	if err != nil {
+		return fmt.Errorf("invalid --%s: %w", flagWindow, err)
+	}
+
+	view := newProgressView(os.Stdout, args...)
+	view.Start()
+	client := compare.NewClient(compare.WithToken(githubAccessToken),
+		compare.WithHTTPClient(httpClient), compare.WithConcurrency(0),
+		compare.WithWindows(stat.Windows{Stars: window, History: window}))
+	list, err := client.Audience(compare.WithProgress(c.Context(), view.Update), args...)
+	view.Stop()
+	if err != nil {
+		return err
+	}
+
+	var (
+		buffer       bytes.Buffer
+		stargazers   = overlapMatrix("stargazers", list, stargazerLogins)
+		contributors = overlapMatrix("contributors", list, contributorLogins)
+	)
+	if getExportType(outputFile, styleTermUI) == exportTPCSV {
+		// solve garbled characters
+		buffer.WriteString("\xEF\xBB\xBF")
+		buffer.WriteString(stargazers.RenderCSV())
+		buffer.WriteString("\n\n")
+		buffer.WriteString(contributors.RenderCSV())
+	} else {
+		buffer.WriteString(overlapPairs(list).Render() + "\n")
+		buffer.WriteString(stargazers.Render() + "\n")
+		buffer.WriteString(contributors.Render())
+	}
+
+	return outputOrPrint(outputFile, buffer)
+}
+
+func stargazerLogins(a compare.Audience) []string {
+	return a.Stargazers
+}
+
+func contributorLogins(a compare.Audience) []string {
+	return a.Contributors
+}
+
+// overlapPairs lists the shared stargazers and contributors of every pair, the
+// indexes come with the sizes of the samples since the stargazers are capped.
+func overlapPairs(list []compare.Audience) table.Writer {
+	t := table.NewWriter()
+	t.SetStyle(table.StyleLight)
+	t.AppendHeader(table.Row{"repository", "compared with", "shared stargazers", "stargazer jaccard",
+		"shared contributors", "contributor jaccard"})
+	for i := 0; i < len(list); i++ {
+		for j := i + 1; j < len(list); j++ {
+			a, b := list[i], list[j]
+			stars, starIndex := formatOverlap(a.Stargazers, b.Stargazers)
+			contributors, contributorIndex := formatOverlap(a.Contributors, b.Contributors)
+			t.AppendRow(table.Row{a.FullName, b.FullName, stars, starIndex, contributors,
+				contributorIndex})
+		}
+	}
+	return t
+}
+
+// overlapMatrix tabulates the Jaccard indexes of the logins of every pair,
+// the diagonal is the number of the logins, the cells of an unknown audience
+// are N/A.
+func overlapMatrix(title string, list []compare.Audience,
+	logins func(compare.Audience) []string) table.Writer {
+	header := table.Row{title}
+	for _, e := range list {
+		header = append(header, e.FullName)
+	}
+
+	t := table.NewWriter()
+	t.SetStyle(table.StyleLight)
+	t.AppendHeader(header)
+	for _, a := range list {
+		row := table.Row{a.FullName}
+		for _, b := range list {
+			if logins(a) == nil || logins(b) == nil {
+				row = append(row, "N/A")
+				continue
+			}
+			if a.FullName == b.FullName {
+				row = append(row, len(logins(a)))
+				continue
+			}
+			_, index := stat.Jaccard(logins(a), logins(b))
+			row = append(row, formatJaccard(index))
+		}
+		t.AppendRow(row)
+	}
+	return t
+}
+
+func formatJaccard(v float64) string {
+	return fmt.Sprintf("%.3f", v)
+}
+
+// formatOverlap formats the shared logins and the Jaccard index of a and b with
+// the sizes of the samples, both are N/A if a or b is unknown.
+func formatOverlap(a, b []string) (string, string) {
+	if a == nil || b == nil {
+		return "N/A", "N/A"
+	}
+
+	shared, index := stat.Jaccard(a, b)
+	return strconv.Itoa(shared), fmt.Sprintf("%s (n=%d/%d)", formatJaccard(index), len(a),
+		len(b))
+}
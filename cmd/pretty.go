@@ -31,14 +31,58 @@ const (
 	flagFileShortHand  = "f"
 	flagToken          = "token"
 	flagTokenShortHand = "t"
+	flagWeights        = "weights"
+	flagSort           = "sort"
+	flagAsc            = "asc"
+	flagFields         = "fields"
+	flagExclude        = "exclude"
+	flagPreset         = "preset"
+	flagConfig         = "config"
+	flagLayout         = "layout"
+	flagRules          = "rules"
+	flagRulesShortHand = "r"
+	flagIndirect       = "indirect"
+	flagDev            = "dev"
+	flagConcurrent     = "concurrency"
+	flagHistory        = "history"
+	flagWindow         = "window"
+	flagState          = "state"
+	flagWebhook        = "webhook"
+	flagPayload        = "payload"
+	flagInterval       = "interval"
 	rootCMDDesc        = "A GitHub repositories statistics command-line tool for the terminal"
+	checkCMDDesc       = "Check repositories against the rules and exit with non-zero code on failure"
+	overlapCMDDesc     = "Compare the shared stargazers and contributors of repositories"
+	monitorCMDDesc     = "Watch repositories and post to a webhook when the rules fire"
+	depsCMDDesc        = "Compare the github hosted dependencies of go.mod, package.json, requirements.txt or Cargo.toml"
 	flagTokenDesc      = "github access token"
 	flagTermUIDesc     = "print with term ui style(default)"
 	flagJSONDesc       = "print with json style"
 	flagYAMLDesc       = "print with yaml style"
 	flagFileDesc       = "output to a specified file"
+	flagWeightsDesc    = "a yaml file which specifies the weights of score components"
+	flagSortDesc       = "sort repositories by a metric, e.g. stars, forks, contributors, lastPush, score"
+	flagAscDesc        = "sort in ascending order, it works with --sort"
+	flagFieldsDesc     = "choose and order the rows of table and csv output, e.g. stars,forks,issues,score"
+	flagExcludeDesc    = "exclude the specified rows from table and csv output"
+	flagPresetDesc     = "use the fields of a named preset in config file"
+	flagConfigDesc     = "config file (default $HOME/.config/github-compare/config.yaml)"
+	flagLayoutDesc     = "table layout, columns: a column per repository, rows: a row per repository"
+	flagRulesDesc      = "a yaml file which contains the rules to check"
+	flagIndirectDesc   = "include indirect dependencies"
+	flagDevDesc        = "include dev and build dependencies"
+	flagConcurrentDesc = "max requests in flight across all repositories, 0 means unlimited"
+	flagHistoryDesc    = "the window of the commit history analysis, e.g. 90d, 26w, 1y (default 1y)"
+	flagWindowDesc     = "the window of the stargazers and contributors, e.g. 30d, 26w, 1y"
+	flagStateDesc      = "a json file which keeps the metrics and the firing rules between runs"
+	flagWebhookDesc    = "the url which the alerts are posted to"
+	flagPayloadDesc    = "the payload of webhook, generic, slack or matrix"
+	flagIntervalDesc   = "run every interval until interrupted, e.g. 1h, 1d, it runs once by default"
 
 	styleJSON   style = "json"
 	styleYAML   style = "yaml"
 	styleTermUI style = "ui"
+
+	layoutColumns = "columns"
+	layoutRows    = "rows"
 )
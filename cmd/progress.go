@@ -0,0 +1,207 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anqiansong/github-compare/pkg/stat"
+	"github.com/briandowns/spinner"
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+)
+
+type (
+	// progressView prints a line per repository with the state of every fetch
+	// stage, it's redrawn in place until stopped.
+	progressView struct {
+		lock   sync.Mutex
+		out    io.Writer
+		repos  []string
+		width  int
+		stages map[string]map[stat.Stage]*stageState
+		lines  int
+		frame  int
+		failed bool
+		stop   chan struct{}
+		done   chan struct{}
+	}
+
+	stageState struct {
+		page int
+		done bool
+		err  error
+	}
+)
+
+var (
+	progressFrames = spinner.CharSets[14]
+	stageOK        = color.New(color.FgGreen)
+	stageFailed    = color.New(color.FgRed)
+	stageRunning   = color.New(color.FgYellow)
+)
+
+func newProgressView(out io.Writer, repos ...string) *progressView {
+	var width int
+	for _, e := range repos {
+		if len(e) > width {
+			width = len(e)
+		}
+	}
+
+	return &progressView{
+		out:    out,
+		repos:  repos,
+		width:  width,
+		stages: make(map[string]map[stat.Stage]*stageState),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Update receives the events of stat, it's safe for concurrent use.
+func (p *progressView) Update(e stat.Event) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	m, ok := p.stages[e.Repo]
+	if !ok {
+		m = make(map[stat.Stage]*stageState)
+		p.stages[e.Repo] = m
+	}
+	s, ok := m[e.Stage]
+	if !ok {
+		s = &stageState{}
+		m[e.Stage] = s
+	}
+
+	if e.Page > 0 {
+		s.page = e.Page
+	}
+	if e.Done {
+		s.done = true
+		s.err = e.Err
+		if e.Err != nil {
+			p.failed = true
+		}
+	}
+}
+
+// Start draws the view until Stop, it does nothing if the output is not a
+// terminal.
+func (p *progressView) Start() {
+	if f, ok := p.out.(*os.File); !ok || !isatty.IsTerminal(f.Fd()) {
+		close(p.done)
+		return
+	}
+
+	// hide the cursor and disable line wrapping so that a line never takes
+	// more than one row.
+	fmt.Fprint(p.out, "\033[?25l\033[?7l")
+	go func() {
+		defer close(p.done)
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			p.render()
+			select {
+			case <-p.stop:
+				p.finish()
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops drawing, the view is erased unless some stages failed.
+func (p *progressView) Stop() {
+	close(p.stop)
+	<-p.done
+}
+
+func (p *progressView) render() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.frame++
+	var b strings.Builder
+	if p.lines > 0 {
+		fmt.Fprintf(&b, "\033[%dA", p.lines)
+	}
+	for _, e := range p.repos {
+		b.WriteString("\r\033[2K")
+		b.WriteString(p.line(e))
+		b.WriteString("\n")
+	}
+	p.lines = len(p.repos)
+	fmt.Fprint(p.out, b.String())
+}
+
+func (p *progressView) finish() {
+	p.render()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.failed && p.lines > 0 {
+		fmt.Fprintf(p.out, "\033[%dA\033[J", p.lines)
+	}
+	fmt.Fprint(p.out, "\033[?7h\033[?25h")
+}
+
+// line must be called with lock held.
+func (p *progressView) line(repo string) string {
+	var (
+		m      = p.stages[repo]
+		icon   = progressFrames[p.frame%len(progressFrames)]
+		stages []string
+	)
+
+	for _, stage := range stat.Stages {
+		s, ok := m[stage]
+		if !ok {
+			continue
+		}
+
+		switch {
+		case s.err != nil:
+			icon = stageFailed.Sprint("✘")
+			stages = append(stages, stageFailed.Sprintf("✘ %s: %v", stage, s.err))
+		case s.done:
+			stages = append(stages, stageOK.Sprintf("✔ %s", stage))
+		case s.page > 1:
+			stages = append(stages, stageRunning.Sprintf("%s page %d", stage, s.page))
+		default:
+			stages = append(stages, stageRunning.Sprintf("%s", stage))
+		}
+	}
+
+	return fmt.Sprintf("%s %-*s  %s", icon, p.width, repo, strings.Join(stages, "  "))
+}
@@ -26,7 +26,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/anqiansong/github-compare/pkg/stat"
 	ui "github.com/dcorbe/termui-dpc"
@@ -36,6 +39,13 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+const (
+	// forecastDays is the days projected in the forecast plot.
+	forecastDays = 90
+	// forecastStep is the days between two points of the forecast plot.
+	forecastStep = 3
+)
+
 func render(printStyle style, list ...stat.Data) error {
 	var prettyText string
 	switch printStyle {
@@ -50,7 +60,7 @@ func render(printStyle style, list ...stat.Data) error {
 			return renderDetail(list[0])
 		}
 
-		t, err := createTable(list, true, false)
+		t, err := createTable(list, true, false, highlightTerm)
 		if err != nil {
 			return err
 		}
@@ -75,48 +85,58 @@ func convert2ViperList(list []stat.Data) ([]*viper.Viper, error) {
 	return data, nil
 }
 
-func createTable(list []stat.Data, emoji bool, exportCSV bool) (table.Writer, error) {
+func createTable(list []stat.Data, emoji bool, exportCSV bool, mark highlighter) (table.Writer, error) {
 	data, err := convert2ViperList(list)
 	if err != nil {
 		return nil, err
 	}
 
-	t := table.NewWriter()
-	t.AppendHeader(createRow("name", "fullName", false, data...))
-	if exportCSV {
-		t.AppendRows([]table.Row{
-			createRow("description", "description", false, data...),
-			createRow("tags", "tags", false, data...),
-			createRow("latestMonthStargazers", "latestMonthStargazers.data", false, data...),
-			createRow("latestWeekForks", "latestWeekForks.data", false, data...),
-			createRow("latestWeekCommits", "latestWeekCommits.data", false, data...),
-			createRow("latestWeekIssues", "latestWeekIssues.data", false, data...),
-		})
-	}
-	t.AppendRows([]table.Row{
-		createRow("homepage", "homepage", emoji, data...),
-		createRow("language", "language", emoji, data...),
-		createRow("license", "license", emoji, data...),
-		createRow("age", "age", emoji, data...),
-		createRow("stars", "starCount", emoji, data...),
-		createRow("latestDayStarCount", "latestDayStarCount", emoji, data...),
-		createRow("latestWeekStarCount", "latestWeekStarCount", emoji, data...),
-		createRow("latestMonthStarCount", "latestMonthStarCount", emoji, data...),
-		createRow("forks", "forkCount", emoji, data...),
-		createRow("watchers", "watcherCount", emoji, data...),
-		createRow("issues", "issue", emoji, data...),
-		createRow("pull requests", "pull", emoji, data...),
-		createRow("contributors", "contributorCount", emoji, data...),
-		createRow("releases", "releaseCount", emoji, data...),
-		createRow("release circle(avg)", "avgReleasePeriod", emoji, data...),
-		createRow("lastRelease", "latestReleaseAt", emoji, data...),
-		createRow("lastCommit", "lastPushedAt", emoji, data...),
-		createRow("lastUpdate", "lastUpdatedAt", emoji, data...),
-	})
+	header := createRow("name", "fullName", false, data...)
+	for i, e := range list {
+		// the exported tables keep the plain names
+		if emoji && e.Metrics.Archived {
+			header[i+1] = fmt.Sprintf("%v (⚠ ARCHIVED)", header[i+1])
+		}
+	}
+	var rows []table.Row
+	for _, r := range getTableRows(exportCSV) {
+		if r.name == fieldRank && (len(list) == 0 || list[0].Rank == 0) {
+			continue
+		}
 
+		var row table.Row
+		if r.format != nil {
+			row = createFormattedRow(r.title, r.field, emoji, r.format, list...)
+		} else {
+			row = createRow(r.title, r.field, emoji && !r.csvOnly, data...)
+		}
+		rows = append(rows, highlightWinner(row, r.winner, mark, list))
+	}
+
+	if layout == layoutRows {
+		header, rows = transpose(header, rows)
+	}
+
+	t := table.NewWriter()
+	t.AppendHeader(header)
+	t.AppendRows(rows)
 	return t, nil
 }
 
+// transpose turns the metrics into columns and the repositories into rows.
+func transpose(header table.Row, rows []table.Row) (table.Row, []table.Row) {
+	matrix := append([]table.Row{header}, rows...)
+	ret := make([]table.Row, len(header))
+	for i := range ret {
+		ret[i] = make(table.Row, len(matrix))
+		for j, row := range matrix {
+			ret[i][j] = row[i]
+		}
+	}
+
+	return ret[0], ret[1:]
+}
+
 func convert2Viper(e stat.Data) (*viper.Viper, error) {
 	v := viper.New()
 	v.SetConfigType("json")
@@ -137,6 +157,7 @@ func convert2Viper(e stat.Data) (*viper.Viper, error) {
 var emojiMap = map[string]string{
 	"homepage":             "🏠 ",
 	"language":             "🌎 ",
+	"languageBreakdown":    "🎨 ",
 	"license":              "📌 ",
 	"age":                  "⏰ ",
 	"starCount":            "🌟 ",
@@ -153,6 +174,40 @@ var emojiMap = map[string]string{
 	"latestReleaseAt":      "🎯 ",
 	"lastPushedAt":         "🕦 ",
 	"lastUpdatedAt":        "📝 ",
+	"pullMergeRatio":       "🔀 ",
+	"pullMergeTime":        "⏱ ",
+	"pullFirstResponse":    "💬 ",
+	"firstTimePullShare":   "🌱 ",
+	"openPullAge":          "📬 ",
+	"issueFirstResponse":   "🙋 ",
+	"issueCloseTime":       "✅ ",
+	"staleIssueRatio":      "🕸 ",
+	"issueBacklog":         "📚 ",
+	"topContributors":      "🏅 ",
+	"busFactor":            "🚌 ",
+	"activeMaintainers":    "🔧 ",
+	"organizations":        "🏢 ",
+	"weekendCommits":       "🏖 ",
+	"officeHourCommits":    "💼 ",
+	"commitTimeZones":      "🕰 ",
+	"releaseInterval":      "⏳ ",
+	"releaseTrend":         "📐 ",
+	"releaseCadence":       "🏷 ",
+	"lastStableRelease":    "🚀 ",
+	"releaseDownloads":     "📥 ",
+	"lowSignalStars":       "🤖 ",
+	"starBursts":           "💥 ",
+	"starProjection":       "🔮 ",
+	"notableEvents":        "📣 ",
+	"forkProjection":       "🧭 ",
+	"archived":             "🗄 ",
+	"repositoryKind":       "🧬 ",
+	"communityHealth":      "🩺 ",
+	"community":            "📋 ",
+	"funding":              "💰 ",
+	"rank":                 "🥇 ",
+	"score.total":          "🏆 ",
+	"score.breakdown":      "🧮 ",
 }
 
 func createRow(title string, field string, emoji bool, data ...*viper.Viper) table.Row {
@@ -168,6 +223,126 @@ func createRow(title string, field string, emoji bool, data ...*viper.Viper) tab
 	return ret
 }
 
+func createFormattedRow(title string, field string, emoji bool, format func(stat.Data) string,
+	list ...stat.Data) table.Row {
+	if emoji {
+		title = emojiMap[field] + title
+	}
+
+	ret := table.Row{title}
+	for _, e := range list {
+		ret = append(ret, format(e))
+	}
+
+	return ret
+}
+
+func formatScoreBreakdown(e stat.Data) string {
+	score := e.Score
+	if score == nil {
+		return "N/A"
+	}
+
+	var lines []string
+	for _, k := range stat.ScoreItems {
+		lines = append(lines, fmt.Sprintf("%s: %.1f", k, score.Breakdown[k]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatCommunity formats the community checklist with a line per item.
+func formatCommunity(e stat.Data) string {
+	if e.Community == nil {
+		return "N/A"
+	}
+
+	var lines []string
+	for _, item := range e.Community.Checklist() {
+		mark := "✘"
+		if item.Present {
+			mark = "✔"
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", mark, item.Name))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// communityLines lays out the community checklist with 3 items per line.
+func communityLines(c *stat.Community) []string {
+	if c == nil {
+		return []string{"N/A"}
+	}
+
+	var (
+		lines []string
+		items []string
+	)
+	for _, e := range c.Checklist() {
+		if e.Present {
+			items = append(items, fmt.Sprintf("[✔ %s](fg:green)", e.Name))
+		} else {
+			items = append(items, fmt.Sprintf("[✘ %s](fg:red)", e.Name))
+		}
+		if len(items) == 3 {
+			lines = append(lines, strings.Join(items, "  "))
+			items = nil
+		}
+	}
+	if len(items) > 0 {
+		lines = append(lines, strings.Join(items, "  "))
+	}
+	return lines
+}
+
+// formatPunchCard formats the commits of day by hour.
+func formatPunchCard(day time.Weekday) func(stat.Data) string {
+	return func(e stat.Data) string {
+		if e.PunchCard == nil {
+			return "N/A"
+		}
+
+		var list []string
+		for _, c := range e.PunchCard.Matrix[day] {
+			list = append(list, strconv.Itoa(c))
+		}
+		return strings.Join(list, " ")
+	}
+}
+
+var heatmapShades = []rune{'·', '░', '▒', '▓', '█'}
+
+// heatmapLines draws the punch card from Monday to Sunday with a column per
+// hour.
+func heatmapLines(p *stat.PunchCard) []string {
+	if p == nil {
+		return []string{"N/A"}
+	}
+
+	var maxVal int
+	for _, row := range p.Matrix {
+		for _, e := range row {
+			if e > maxVal {
+				maxVal = e
+			}
+		}
+	}
+
+	lines := []string{fmt.Sprintf("    %-6s%-6s%-6s%-6s", "0", "6", "12", "18")}
+	for i := 1; i <= 7; i++ {
+		day := time.Weekday(i % 7)
+		var b strings.Builder
+		for _, e := range p.Matrix[day] {
+			level := 0
+			if maxVal > 0 {
+				level = (e*(len(heatmapShades)-1) + maxVal - 1) / maxVal
+			}
+			b.WriteRune(heatmapShades[level])
+		}
+		lines = append(lines, fmt.Sprintf("%s [%s](fg:green)", day.String()[:3], b.String()))
+	}
+	return lines
+}
+
 func renderDetail(st stat.Data) error {
 	data, err := convert2Viper(st)
 	if err != nil {
@@ -180,19 +355,18 @@ func renderDetail(st stat.Data) error {
 	defer ui.Close()
 
 	starBar := createBarChart(st.LatestMonthStargazers,
-		"Stars (Latest Month) [PRESS [Q | CTRL+C | ESC] TO QUIT]", ui.ColorRed,
-		func() []ui.Color {
-			var colorList []ui.Color
-			for i := 1; i < 18; i++ {
-				colorList = append(colorList, ui.Color(i))
-			}
-			return colorList
-		}()...)
+		"Stars (Latest Month, Bursts In Red) [PRESS [Q | CTRL+C | ESC] TO QUIT]", ui.ColorRed,
+		starColors(st.LatestMonthStargazers)...)
+
+	forecast := createForecastPlot(st.LatestMonthStargazers, st.StarGrowth)
 
 	forkBar := createBarChart(st.LatestWeekForks, "Forks (Latest Week)", ui.ColorGreen)
 	commitBar := createBarChart(st.LatestWeekCommits, "Commits (Latest Week)", ui.ColorYellow)
 	pullBar := createBarChart(st.LatestWeekPulls, "Pulls (Latest Week)", ui.ColorWhite)
 	issueBar := createBarChart(st.LatestWeekIssues, "Issues (Latest Week)", ui.ColorCyan)
+	releaseBar := createBarChart(st.ReleaseTimeline,
+		fmt.Sprintf("Releases (Median Interval %s)", st.ReleaseInterval), ui.ColorMagenta)
+	releaseBar.BarWidth = 2
 
 	desc := creatParagraph("About", ui.ColorYellow, func() []string {
 		return []string{
@@ -202,6 +376,19 @@ func renderDetail(st stat.Data) error {
 		}
 	}()...)
 	desc.TextStyle = ui.NewStyle(ui.ColorGreen)
+	if st.Metrics.Archived {
+		desc.Title = "About [⚠ ARCHIVED, NO LONGER MAINTAINED]"
+		desc.TitleStyle = ui.NewStyle(ui.ColorRed, ui.ColorClear, ui.ModifierBold)
+		desc.BorderStyle = ui.NewStyle(ui.ColorRed)
+	}
+
+	events := creatParagraph("Notable Events (▲ Spike, ▼ Drop)", ui.ColorMagenta,
+		strings.Split(data.GetString("notableEvents"), "\n")...)
+	events.TextStyle = ui.NewStyle(ui.ColorMagenta)
+
+	community := creatParagraph(fmt.Sprintf("Community (%s, %s)",
+		data.GetString("communityHealth"), data.GetString("repositoryKind")), ui.ColorBlue,
+		communityLines(st.Community)...)
 
 	metrics1 := creatParagraph("Metrics1", ui.ColorRed, func() []string {
 		return []string{
@@ -240,24 +427,64 @@ func renderDetail(st stat.Data) error {
 		}
 	}()...)
 
+	pulls := creatParagraph("Pull Requests", ui.ColorWhite, func() []string {
+		return []string{
+			fmt.Sprintf("[◉ Merged: %s](fg:red)", data.GetString("pullMergeRatio")),
+			fmt.Sprintf("[◉ TimeToMerge: %s](fg:green)", data.GetString("pullMergeTime")),
+			fmt.Sprintf("[◉ FirstResponse: %s](fg:yellow)", data.GetString("pullFirstResponse")),
+			fmt.Sprintf("[◉ FirstTimeContributors: %s](fg:cyan)",
+				data.GetString("firstTimePullShare")),
+			fmt.Sprintf("[◉ OpenAge: %s](fg:white)", data.GetString("openPullAge")),
+		}
+	}()...)
+
+	issues := creatParagraph("Issues", ui.ColorMagenta, func() []string {
+		return []string{
+			fmt.Sprintf("[◉ FirstMaintainerResponse: %s](fg:red)",
+				data.GetString("issueFirstResponse")),
+			fmt.Sprintf("[◉ TimeToClose: %s](fg:green)", data.GetString("issueCloseTime")),
+			fmt.Sprintf("[◉ ClosedAsStale: %s](fg:yellow)", data.GetString("staleIssueRatio")),
+			fmt.Sprintf("[◉ Backlog: %s](fg:cyan)", data.GetString("issueBacklog")),
+		}
+	}()...)
+
+	heatmap := creatParagraph(fmt.Sprintf("Commits by Weekday and Hour (%s)",
+		st.HistoryWindow), ui.ColorGreen, append(
+		heatmapLines(st.PunchCard),
+		fmt.Sprintf("[◉ TimeZones: %s](fg:cyan)", data.GetString("commitTimeZones")),
+	)...)
+
 	grid := ui.NewGrid()
 	termWidth, termHeight := ui.TerminalDimensions()
 	grid.SetRect(0, 0, termWidth, termHeight)
 	grid.Set(
-		ui.NewRow(1.0/4, ui.NewCol(1.0, starBar)),
-		ui.NewRow(1.0/4,
+		ui.NewRow(0.18,
+			ui.NewCol(0.65, starBar),
+			ui.NewCol(0.35, forecast),
+		),
+		ui.NewRow(0.18,
 			ui.NewCol(1.0/4, forkBar),
 			ui.NewCol(1.0/4, commitBar),
 			ui.NewCol(1.0/4, pullBar),
 			ui.NewCol(1.0/4, issueBar),
 		),
-		ui.NewRow(1.0/4, ui.NewCol(1.0, desc)),
-		ui.NewRow(1.0/4,
+		ui.NewRow(0.13,
+			ui.NewCol(0.3, desc),
+			ui.NewCol(0.35, events),
+			ui.NewCol(0.35, community),
+		),
+		ui.NewRow(0.16,
 			ui.NewCol(1.0/4, metrics1),
 			ui.NewCol(1.0/4, metrics2),
 			ui.NewCol(1.0/4, metrics3),
 			ui.NewCol(1.0/4, metrics4),
 		),
+		ui.NewRow(0.35,
+			ui.NewCol(1.0/4, pulls),
+			ui.NewCol(1.0/4, issues),
+			ui.NewCol(1.0/4, heatmap),
+			ui.NewCol(1.0/4, releaseBar),
+		),
 	)
 	ui.Render(grid)
 
@@ -277,6 +504,101 @@ func renderDetail(st stat.Data) error {
 	}
 }
 
+// starColors colors the bursts in red and the other days in a palette
+// without red and bright red.
+func starColors(chart stat.Chart) []ui.Color {
+	var (
+		colorList []ui.Color
+		palette   []ui.Color
+	)
+	for i := 2; i < 18; i++ {
+		if ui.Color(i) != ui.ColorRed+8 {
+			palette = append(palette, ui.Color(i))
+		}
+	}
+	for i := range chart.Data {
+		if i < len(chart.Highlights) && chart.Highlights[i] {
+			colorList = append(colorList, ui.ColorRed)
+			continue
+		}
+		colorList = append(colorList, palette[i%len(palette)])
+	}
+	if len(colorList) == 0 {
+		return palette
+	}
+	return colorList
+}
+
+// createForecastPlot plots the stars gained in the chart and the projection of
+// the next forecastDays with the 95% band.
+func createForecastPlot(chart stat.Chart, growth *stat.Growth) ui.Drawable {
+	const title = "Star Forecast (+90d, 95% Band)"
+	if growth == nil {
+		return creatParagraph(title, ui.ColorRed, "N/A")
+	}
+
+	var (
+		past   []float64
+		gained float64
+	)
+	for i, e := range chart.Data {
+		gained += e
+		if (len(chart.Data)-1-i)%forecastStep == 0 {
+			past = append(past, gained)
+		}
+	}
+	if len(past) == 0 {
+		past = []float64{0}
+	}
+
+	var (
+		mid    = append([]float64{}, past...)
+		lower  = append([]float64{}, past...)
+		upper  = append([]float64{}, past...)
+		maxVal = gained
+	)
+	for days := forecastStep; days <= forecastDays; days += forecastStep {
+		p := growth.At(days)
+		mid = append(mid, gained+float64(p.Value-growth.Total))
+		lower = append(lower, math.Max(0, gained+float64(p.Lower-growth.Total)))
+		upper = append(upper, gained+float64(p.Upper-growth.Total))
+		maxVal = math.Max(maxVal, upper[len(upper)-1])
+	}
+
+	plot := widgets.NewPlot()
+	plot.Title = title
+	plot.TitleStyle = ui.NewStyle(ui.ColorRed)
+	plot.Data = [][]float64{upper, lower, mid}
+	plot.LineColors = []ui.Color{ui.ColorYellow, ui.ColorYellow, ui.ColorRed}
+	plot.MaxVal = maxVal + 1
+	plot.YAxisFmter = func(v float64) string {
+		return fmt.Sprintf("%.0f", v)
+	}
+	plot.XAxisFmter = func(v int) string {
+		if v > len(mid) {
+			return ""
+		}
+		return fmt.Sprintf("%+d", (v-len(past))*forecastStep)
+	}
+	return plot
+}
+
+// trendLabels marks the labels of the spikes with ▲ and the drops with ▼.
+func trendLabels(chart stat.Chart) []string {
+	var labels []string
+	for i, e := range chart.Labels {
+		switch {
+		case i >= len(chart.Trends):
+		case chart.Trends[i] == stat.TrendSpike:
+			e = "▲" + e
+		case chart.Trends[i] == stat.TrendDrop:
+			e = "▼" + e
+		}
+		labels = append(labels, e)
+	}
+	return labels
+}
+
 var colorString = []string{"black", "red", "green", "blue", "magenta", "cyan"}
 
 func formatTags(tags []string) string {
@@ -311,7 +633,7 @@ func createBarChart(data stat.Chart, title string, titleColor ui.Color,
 	bar := widgets.NewBarChart()
 	bar.Title = title
 	bar.Data = data.Data
-	bar.Labels = data.Labels
+	bar.Labels = trendLabels(data)
 	bar.MaxVal = maxVal()
 	bar.TitleStyle = ui.NewStyle(titleColor)
 	if len(barColors) > 0 {
@@ -23,13 +23,19 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
-	"time"
+	"syscall"
 
+	"github.com/anqiansong/github-compare/pkg/compare"
+	"github.com/anqiansong/github-compare/pkg/sched"
 	"github.com/anqiansong/github-compare/pkg/stat"
-	"github.com/briandowns/spinner"
+	"github.com/anqiansong/github-compare/pkg/timex"
 	"github.com/spf13/cobra"
 )
 
@@ -38,17 +44,44 @@ var (
 	jsonStyle         bool
 	termUIStyle       bool
 	yamlStyle         bool
+	weightsFile       string
+	sortKey           string
+	sortAsc           bool
+	fields            []string
+	excludeFields     []string
+	preset            string
+	configFile        string
+	layout            string
+	concurrency       int
+	historyWindow     string
 
 	rootCmd = &cobra.Command{
 		Use:   "github-compare",
 		Short: rootCMDDesc,
-		Args:  cobra.RangeArgs(1, 4),
+		Args:  cobra.MinimumNArgs(1),
 		RunE:  run,
+		PersistentPreRunE: func(c *cobra.Command, _ []string) error {
+			scheduler.SetMax(concurrency)
+			return applyConfig(c)
+		},
 	}
+
+	// scheduler limits the requests of all the commands in flight.
+	scheduler  = sched.New(0)
+	httpClient = &http.Client{Transport: scheduler.Transport(nil)}
 )
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		// a second Ctrl-C kills the process immediately
+		<-ctx.Done()
+		stop()
+	}()
+
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	if err != nil {
 		os.Exit(codeFailure)
 	}
 }
@@ -62,6 +95,10 @@ func getExportType(outputFile string, printStyle style) string {
 		return exportTPYAML
 	case "csv":
 		return exportTPCSV
+	case "md", "markdown":
+		return exportTPMarkdown
+	case "html", "htm":
+		return exportTPHTML
 	default:
 		if printStyle != styleTermUI {
 			return string(printStyle)
@@ -81,12 +118,39 @@ func getPrintStyle() style {
 	}
 }
 
-func getData(renderColor bool, args ...string) ([]stat.Data, error) {
-	s := spinner.New(spinner.CharSets[14], 100*time.Millisecond) // Build our new spinner
-	s.Suffix = " Loading..."
-	s.Start() // Start the spinner
-	data := stat.Overview(githubAccessToken, renderColor, args...)
-	s.Stop()
+func getData(ctx context.Context, renderColor bool, args ...string) ([]stat.Data, error) {
+	windows := configWindows
+	if len(historyWindow) > 0 {
+		d, err := timex.ParseDuration(historyWindow)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s: %w", flagHistory, err)
+		}
+		windows.History = d
+	}
+
+	view := newProgressView(os.Stdout, args...)
+	view.Start()
+	defer view.Stop()
+	ctx = compare.WithProgress(ctx, view.Update)
+
+	client := compare.NewClient(compare.WithToken(githubAccessToken),
+		compare.WithForgeTokens(gitlabToken, giteaToken),
+		compare.WithHTTPClient(httpClient), compare.WithConcurrency(0),
+		compare.WithDetail(len(args) == 1), compare.WithWindows(windows))
+	results, err := client.Fetch(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]stat.Data, 0, len(results))
+	for _, r := range results {
+		d := stat.NewData(r, client.Windows())
+		if renderColor {
+			colorize(&d, r)
+		}
+		data = append(data, d)
+	}
+
 	return data, nil
 }
 
@@ -99,22 +163,86 @@ func init() {
 	persistentFlags.BoolVar(&yamlStyle, styleYAML, false, flagYAMLDesc)
 	persistentFlags.StringVarP(&outputFile, flagFile, flagFileShortHand, defaultEmptyString,
 		flagFileDesc)
+	persistentFlags.StringVar(&weightsFile, flagWeights, defaultEmptyString, flagWeightsDesc)
+	persistentFlags.StringVar(&sortKey, flagSort, defaultEmptyString, flagSortDesc)
+	persistentFlags.BoolVar(&sortAsc, flagAsc, false, flagAscDesc)
+	persistentFlags.StringSliceVar(&fields, flagFields, nil, flagFieldsDesc)
+	persistentFlags.StringSliceVar(&excludeFields, flagExclude, nil, flagExcludeDesc)
+	persistentFlags.StringVar(&preset, flagPreset, defaultEmptyString, flagPresetDesc)
+	persistentFlags.StringVar(&configFile, flagConfig, defaultEmptyString, flagConfigDesc)
+	persistentFlags.StringVar(&layout, flagLayout, layoutColumns, flagLayoutDesc)
+	persistentFlags.IntVar(&concurrency, flagConcurrent, 8, flagConcurrentDesc)
+	persistentFlags.StringVar(&historyWindow, flagHistory, defaultEmptyString, flagHistoryDesc)
 	rootCmd.Version = version
 }
 
-func run(_ *cobra.Command, args []string) error {
+func setupFields() error {
+	if len(preset) > 0 && len(fields) > 0 {
+		return fmt.Errorf("--%s and --%s can not be used together", flagFields, flagPreset)
+	}
+
+	selected := fields
+	if len(preset) > 0 {
+		var err error
+		selected, err = loadedConfig.preset(preset)
+		if err != nil {
+			return err
+		}
+	}
+
+	return selectRows(selected, excludeFields)
+}
+
+func run(c *cobra.Command, args []string) error {
+	args, err := expandGroups(args...)
+	if err != nil {
+		return err
+	}
+
+	args = normalizeRepos(args...)
 	if err := validateGithubRepo(args...); err != nil {
 		return err
 	}
 
+	return compareRepos(c.Context(), args...)
+}
+
+// compareRepos fetches the statistics of repos and prints or exports them.
+func compareRepos(ctx context.Context, repos ...string) error {
+	if layout != layoutColumns && layout != layoutRows {
+		return fmt.Errorf("invalid layout %q, expected %s or %s", layout, layoutColumns, layoutRows)
+	}
+
+	if len(sortKey) > 0 {
+		if err := stat.CheckSortKey(sortKey); err != nil {
+			return err
+		}
+	}
+
+	if err := setupFields(); err != nil {
+		return err
+	}
+
+	weights, err := stat.LoadScoreWeights(weightsFile)
+	if err != nil {
+		return err
+	}
+
 	printStyle := getPrintStyle()
 	// Only rendering color when print to terminal and there are more than 1 repositories
-	renderColor := printStyle == styleTermUI && len(outputFile) == 0 && len(args) > 1
-	data, err := getData(renderColor, args...)
+	renderColor := printStyle == styleTermUI && len(outputFile) == 0 && len(repos) > 1
+	data, err := getData(ctx, renderColor, repos...)
 	if err != nil {
 		return err
 	}
 
+	stat.Rate(data, weights)
+	if len(sortKey) > 0 {
+		if err := stat.Sort(data, sortKey, sortAsc); err != nil {
+			return err
+		}
+	}
+
 	if len(outputFile) > 0 {
 		tp := getExportType(outputFile, printStyle)
 		return export(data, tp)
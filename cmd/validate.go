@@ -25,13 +25,35 @@ package cmd
 import (
 	"fmt"
 	"regexp"
+	"strings"
+
+	"github.com/anqiansong/github-compare/pkg/stat"
+)
+
+const (
+	repoRegex    = `(?m)^[\w-]+\/[\w.-]+`
+	githubPrefix = "https://github.com/"
 )
 
-const repoRegex = `(?m)^[\w-]+\/[\w-]+`
+// normalizeRepos turns github urls into owner/repo.
+func normalizeRepos(name ...string) []string {
+	var ret []string
+	for _, e := range name {
+		if strings.HasPrefix(e, githubPrefix) {
+			e = strings.TrimSuffix(strings.Trim(strings.TrimPrefix(e, githubPrefix), "/"), ".git")
+		}
+		ret = append(ret, e)
+	}
+	return ret
+}
 
 func validateGithubRepo(name ...string) error {
 	re := regexp.MustCompile(repoRegex)
 	for _, e := range name {
+		if stat.IsExternal(e) {
+			continue
+		}
+
 		all := re.FindAllString(e, -1)
 		if len(all) > 0 && all[0] == e {
 			continue
@@ -10,6 +10,7 @@ require (
 	github.com/google/go-github/v44 v44.1.1-0.20220516192235-2d872b40760d
 	github.com/jedib0t/go-pretty/v6 v6.3.1
 	github.com/kevwan/mapreduce/v2 v2.1.1
+	github.com/mattn/go-isatty v0.0.14
 	github.com/shurcooL/githubv4 v0.0.0-20220520033151-0b4e3294ff00
 	github.com/spf13/cobra v1.4.0
 	github.com/spf13/viper v1.11.0
@@ -26,7 +27,6 @@ require (
 	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
 	github.com/magiconair/properties v1.8.6 // indirect
 	github.com/mattn/go-colorable v0.1.12 // indirect
-	github.com/mattn/go-isatty v0.0.14 // indirect
 	github.com/mattn/go-runewidth v0.0.13 // indirect
 	github.com/mitchellh/go-wordwrap v1.0.1 // indirect
 	github.com/mitchellh/mapstructure v1.4.3 // indirect
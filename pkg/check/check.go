@@ -0,0 +1,241 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package check
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anqiansong/github-compare/pkg/stat"
+	"github.com/anqiansong/github-compare/pkg/timex"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	opLT = "<"
+	opLE = "<="
+	opGT = ">"
+	opGE = ">="
+	opEQ = "=="
+	opNE = "!="
+)
+
+var ruleRegex = regexp.MustCompile(`^\s*([\w.]+)\s*(<=|>=|==|!=|<|>)\s*(.+?)\s*$`)
+
+type (
+	// Rule is a single expression like `lastPushedAt < 180d`.
+	Rule struct {
+		Expr  string
+		Field string
+		Op    string
+		Value string
+	}
+
+	Result struct {
+		Repo   string
+		Rule   Rule
+		Actual string
+		Passed bool
+		Err    error
+	}
+
+	ruleFile struct {
+		Rules []string `yaml:"rules"`
+	}
+)
+
+// durationFields are measured as the time elapsed since the given time.
+var durationFields = map[string]func(stat.Metrics) time.Time{
+	"age":             func(m stat.Metrics) time.Time { return m.CreatedAt },
+	"lastPush":        func(m stat.Metrics) time.Time { return m.PushedAt },
+	"lastPushedAt":    func(m stat.Metrics) time.Time { return m.PushedAt },
+	"lastRelease":     func(m stat.Metrics) time.Time { return m.LatestReleaseAt },
+	"latestReleaseAt": func(m stat.Metrics) time.Time { return m.LatestReleaseAt },
+	"lastUpdate":      func(m stat.Metrics) time.Time { return m.UpdatedAt },
+	"lastUpdatedAt":   func(m stat.Metrics) time.Time { return m.UpdatedAt },
+}
+
+var stringFields = map[string]func(stat.Data) string{
+	"fullName": func(d stat.Data) string { return d.FullName },
+	"language": func(d stat.Data) string { return d.Language },
+	"license":  func(d stat.Data) string { return d.License },
+}
+
+func LoadRules(file string) ([]Rule, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var f ruleFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	if len(f.Rules) == 0 {
+		return nil, fmt.Errorf("no rules found in %s", file)
+	}
+
+	var rules []Rule
+	for _, e := range f.Rules {
+		r, err := ParseRule(e)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+
+	return rules, nil
+}
+
+func ParseRule(expr string) (Rule, error) {
+	match := ruleRegex.FindStringSubmatch(expr)
+	if len(match) != 4 {
+		return Rule{}, fmt.Errorf("invalid rule %q", expr)
+	}
+
+	r := Rule{Expr: strings.TrimSpace(expr), Field: match[1], Op: match[2],
+		Value: strings.Trim(match[3], `"'`)}
+	switch {
+	case durationFields[r.Field] != nil:
+		if _, err := timex.ParseDuration(r.Value); err != nil {
+			return Rule{}, fmt.Errorf("invalid rule %q: %w", expr, err)
+		}
+	case stringFields[r.Field] != nil:
+		if r.Op != opEQ && r.Op != opNE {
+			return Rule{}, fmt.Errorf("invalid rule %q: %s only supports %s and %s", expr,
+				r.Field, opEQ, opNE)
+		}
+	default:
+		if err := stat.CheckSortKey(r.Field); err != nil {
+			return Rule{}, fmt.Errorf("invalid rule %q: unknown field %q", expr, r.Field)
+		}
+		if _, err := strconv.ParseFloat(r.Value, 64); err != nil {
+			return Rule{}, fmt.Errorf("invalid rule %q: %q is not a number", expr, r.Value)
+		}
+	}
+
+	return r, nil
+}
+
+func (r Rule) String() string {
+	return r.Expr
+}
+
+// Eval checks the rule against d and returns the actual value.
+func (r Rule) Eval(d stat.Data) (bool, string, error) {
+	if fn, ok := durationFields[r.Field]; ok {
+		expected, err := timex.ParseDuration(r.Value)
+		if err != nil {
+			return false, "", err
+		}
+
+		at := fn(d.Metrics)
+		if at.IsZero() {
+			return false, "N/A", nil
+		}
+
+		actual := time.Since(at)
+		return Compare(float64(actual), r.Op, float64(expected)), formatDuration(actual), nil
+	}
+
+	if fn, ok := stringFields[r.Field]; ok {
+		actual := fn(d)
+		equal := strings.EqualFold(actual, r.Value)
+		if r.Op == opEQ {
+			return equal, actual, nil
+		}
+		return !equal, actual, nil
+	}
+
+	actual, ok := stat.Value(d, r.Field)
+	if !ok {
+		return false, "", fmt.Errorf("unknown field %q", r.Field)
+	}
+	expected, err := strconv.ParseFloat(r.Value, 64)
+	if err != nil {
+		return false, "", err
+	}
+	if math.IsNaN(actual) || math.IsInf(actual, 0) {
+		return false, "N/A", nil
+	}
+
+	return Compare(actual, r.Op, expected), formatFloat(actual), nil
+}
+
+// Run evaluates every rule against every repository.
+func Run(rules []Rule, list []stat.Data) []Result {
+	var ret []Result
+	for _, d := range list {
+		for _, r := range rules {
+			passed, actual, err := r.Eval(d)
+			ret = append(ret, Result{
+				Repo:   d.FullName,
+				Rule:   r,
+				Actual: actual,
+				Passed: passed && err == nil,
+				Err:    err,
+			})
+		}
+	}
+	return ret
+}
+
+// Compare reports whether actual op expected holds, e.g. actual >= expected.
+func Compare(actual float64, op string, expected float64) bool {
+	switch op {
+	case opLT:
+		return actual < expected
+	case opLE:
+		return actual <= expected
+	case opGT:
+		return actual > expected
+	case opGE:
+		return actual >= expected
+	case opEQ:
+		return actual == expected
+	case opNE:
+		return actual != expected
+	default:
+		return false
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	days := d.Hours() / 24
+	if days >= 1 {
+		return fmt.Sprintf("%dd", int(days))
+	}
+	return d.Round(time.Minute).String()
+}
+
+func formatFloat(v float64) string {
+	if math.IsInf(v, 0) {
+		return "N/A"
+	}
+	return strconv.FormatFloat(math.Round(v*10000)/10000, 'f', -1, 64)
+}
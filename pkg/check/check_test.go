@@ -0,0 +1,113 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package check
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anqiansong/github-compare/pkg/stat"
+)
+
+func TestParseRule(t *testing.T) {
+	for _, e := range []string{
+		"lastPushedAt < 180d",
+		"license != N/A",
+		"openIssueRatio < 0.5",
+		"contributors >= 3",
+		"age>1y",
+	} {
+		if _, err := ParseRule(e); err != nil {
+			t.Fatalf("unexpected error of %q: %v", e, err)
+		}
+	}
+
+	for _, e := range []string{
+		"lastPushedAt < 180x",
+		"license > MIT",
+		"unknown == 1",
+		"contributors >= many",
+		"contributors",
+	} {
+		if _, err := ParseRule(e); err == nil {
+			t.Fatalf("expected error of %q", e)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	var rules []Rule
+	for _, e := range []string{
+		"lastPushedAt < 180d",
+		"license != N/A",
+		"openIssueRatio < 0.5",
+		"contributors >= 3",
+	} {
+		r, err := ParseRule(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules = append(rules, r)
+	}
+
+	list := []stat.Data{
+		{FullName: "a/a", License: "MIT License", Metrics: stat.Metrics{
+			PushedAt: time.Now().Add(-24 * time.Hour), IssueCount: 10, OpenIssueCount: 1,
+			ContributorCount: 3}},
+		{FullName: "b/b", License: "N/A", Metrics: stat.Metrics{
+			PushedAt: time.Now().Add(-200 * 24 * time.Hour), IssueCount: 10, OpenIssueCount: 6,
+			ContributorCount: 1}},
+	}
+
+	results := Run(rules, list)
+	if len(results) != 8 {
+		t.Fatalf("expected 8 results, got %d", len(results))
+	}
+	for _, e := range results {
+		if e.Passed != (e.Repo == "a/a") {
+			t.Fatalf("unexpected result of %s %s: %v(%s)", e.Repo, e.Rule, e.Passed, e.Actual)
+		}
+	}
+}
+
+func TestEvalUnknown(t *testing.T) {
+	d := stat.Data{FullName: "a/a", Metrics: stat.Metrics{
+		IssueCount: stat.Unknown, OpenIssueCount: stat.Unknown, ContributorCount: stat.Unknown}}
+	for _, e := range []string{
+		"openIssueRatio < 0.5",
+		"contributors < 3",
+		"pullMergeTime > 1",
+	} {
+		r, err := ParseRule(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		passed, actual, err := r.Eval(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if passed || actual != "N/A" {
+			t.Fatalf("expected %s to fail with N/A, got %v(%s)", e, passed, actual)
+		}
+	}
+}
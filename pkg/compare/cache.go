@@ -0,0 +1,93 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package compare
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type (
+	// Cache stores the results by key, it must be safe for concurrent use.
+	Cache interface {
+		Get(key string) (Result, bool)
+		Set(key string, r Result)
+	}
+
+	memoryCache struct {
+		lock    sync.Mutex
+		ttl     time.Duration
+		entries map[string]cacheEntry
+	}
+
+	cacheEntry struct {
+		result   Result
+		expireAt time.Time
+	}
+)
+
+// NewMemoryCache returns a Cache in memory, the results expire after ttl,
+// ttl <= 0 means never.
+func NewMemoryCache(ttl time.Duration) Cache {
+	return &memoryCache{ttl: ttl, entries: make(map[string]cacheEntry)}
+}
+
+func (c *memoryCache) Get(key string) (Result, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return Result{}, false
+	}
+	if !e.expireAt.IsZero() && time.Now().After(e.expireAt) {
+		delete(c.entries, key)
+		return Result{}, false
+	}
+	return e.result, true
+}
+
+func (c *memoryCache) Set(key string, r Result) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var expireAt time.Time
+	if c.ttl > 0 {
+		expireAt = time.Now().Add(c.ttl)
+	}
+	c.entries[key] = cacheEntry{result: r, expireAt: expireAt}
+}
+
+// cacheKey tells the results of different windows and detail apart, all the
+// windows are part of it since every one of them changes the result.
+func (c *Client) cacheKey(repo string) string {
+	return fmt.Sprintf("%s|%+v|%t", repo, c.windows, c.detail)
+}
+
+func (c *Client) cached(repo string) (Result, bool) {
+	if c.cache == nil {
+		return Result{}, false
+	}
+	return c.cache.Get(c.cacheKey(repo))
+}
@@ -0,0 +1,314 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package compare fetches the raw statistics of repositories on GitHub,
+// GitLab, Gitea and disk, it's free of presentation, use stat.NewData to
+// format a Result.
+package compare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/anqiansong/github-compare/pkg/sched"
+	"github.com/anqiansong/github-compare/pkg/stat"
+	"github.com/kevwan/mapreduce/v2"
+	"golang.org/x/oauth2"
+)
+
+const defaultConcurrency = 8
+
+// ErrMissingToken is returned if a GitHub repository is fetched without a token.
+var ErrMissingToken = errors.New("missing access token")
+
+type (
+	// Result is the raw statistics of a repository.
+	Result = stat.Result
+	// Windows are the periods of the time series in Result.
+	Windows = stat.Windows
+	// Event reports the progress of fetching a repository.
+	Event = stat.Event
+	// ProgressFunc receives the events of fetching.
+	ProgressFunc = stat.ProgressFunc
+	// Audience is the stargazers and the contributors of a repository.
+	Audience = stat.Audience
+
+	// Client fetches the statistics of repositories, it's safe for concurrent
+	// use.
+	Client struct {
+		tokenSource oauth2.TokenSource
+		httpClient  *http.Client
+		windows     Windows
+		concurrency int
+		cache       Cache
+		detail      bool
+		transport   http.RoundTripper
+		github      *http.Client
+		gitlabToken string
+		giteaToken  string
+	}
+
+	// Option customizes a Client.
+	Option func(c *Client)
+)
+
+// NewClient returns a Client, the GitHub token is read from the environment
+// GITHUB_ACCESS_TOKEN unless WithToken or WithTokenSource is given.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		httpClient:  &http.Client{},
+		concurrency: defaultConcurrency,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.tokenSource == nil {
+		if token := os.Getenv("GITHUB_ACCESS_TOKEN"); len(token) > 0 {
+			c.tokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+		}
+	}
+
+	c.transport = c.httpClient.Transport
+	if c.transport == nil {
+		c.transport = http.DefaultTransport
+	}
+	if c.concurrency > 0 {
+		c.transport = sched.New(c.concurrency).Transport(c.transport)
+	}
+	if c.tokenSource != nil {
+		c.github = &http.Client{
+			Transport: &oauth2.Transport{Source: oauth2.ReuseTokenSource(nil, c.tokenSource),
+				Base: c.transport},
+			Timeout: c.httpClient.Timeout,
+		}
+	}
+
+	c.windows = c.windows.OrDefault()
+	return c
+}
+
+// WithToken authorizes the GitHub requests by a personal access token.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		if len(token) > 0 {
+			c.tokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+		}
+	}
+}
+
+// WithTokenSource authorizes the GitHub requests by ts.
+func WithTokenSource(ts oauth2.TokenSource) Option {
+	return func(c *Client) {
+		c.tokenSource = ts
+	}
+}
+
+// WithForgeTokens authorizes the GitLab and Gitea requests, the empty ones are
+// read from GITLAB_ACCESS_TOKEN and GITEA_ACCESS_TOKEN.
+func WithForgeTokens(gitlab, gitea string) Option {
+	return func(c *Client) {
+		c.gitlabToken, c.giteaToken = gitlab, gitea
+	}
+}
+
+// WithHTTPClient sends all the requests by client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		if client != nil {
+			c.httpClient = client
+		}
+	}
+}
+
+// WithWindows changes the periods of the time series, the zero periods keep
+// the default ones.
+func WithWindows(w Windows) Option {
+	return func(c *Client) {
+		c.windows = w
+	}
+}
+
+// WithConcurrency limits the requests in flight, n <= 0 means unlimited, it's
+// 8 by default.
+func WithConcurrency(n int) Option {
+	return func(c *Client) {
+		c.concurrency = n
+	}
+}
+
+// WithCache reuses the results in cache.
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// WithDetail fetches the forks and issues of the GitHub repositories too.
+func WithDetail(detail bool) Option {
+	return func(c *Client) {
+		c.detail = detail
+	}
+}
+
+// WithProgress returns a context which reports the progress of Fetch to fn.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return stat.WithProgress(ctx, fn)
+}
+
+// Fetch fetches the statistics of repos in order, a repository is either
+// owner/repo on GitHub, or one which is accepted by stat.IsExternal.
+func (c *Client) Fetch(ctx context.Context, repos ...string) ([]Result, error) {
+	var (
+		m        = make(map[string]Result, len(repos))
+		lock     sync.Mutex
+		github   []string
+		external []string
+	)
+	for _, e := range repos {
+		if r, ok := c.cached(e); ok {
+			m[e] = r
+			continue
+		}
+		if stat.IsExternal(e) {
+			external = append(external, e)
+		} else {
+			github = append(github, e)
+		}
+	}
+	if len(github) > 0 && c.github == nil {
+		return nil, ErrMissingToken
+	}
+
+	store := func(r Result) {
+		lock.Lock()
+		m[r.FullName] = r
+		lock.Unlock()
+		if c.cache != nil {
+			c.cache.Set(c.cacheKey(r.FullName), r)
+		}
+	}
+
+	config := c.config()
+	err := mapreduce.Finish(func() error {
+		if len(github) == 0 {
+			return nil
+		}
+		for _, e := range stat.Fetch(ctx, config, github...) {
+			store(e)
+		}
+		return nil
+	}, func() error {
+		if len(external) == 0 {
+			return nil
+		}
+		return mapreduce.MapReduceVoid(func(source chan<- string) {
+			for _, e := range external {
+				source <- e
+			}
+		}, func(repo string, writer mapreduce.Writer[Result], cancel func(error)) {
+			r, err := stat.FetchExternal(ctx, config, repo)
+			if err != nil {
+				cancel(err)
+				return
+			}
+			writer.Write(r)
+		}, func(pipe <-chan Result, cancel func(error)) {
+			for r := range pipe {
+				store(r)
+			}
+		}, mapreduce.WithContext(ctx))
+	})
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		list    []Result
+		missing []string
+	)
+	for _, e := range repos {
+		if r, ok := m[e]; ok {
+			list = append(list, r)
+		} else {
+			missing = append(missing, e)
+		}
+	}
+	if len(missing) > 0 {
+		return list, fmt.Errorf("could not resolve %s", strings.Join(missing, ", "))
+	}
+
+	return list, nil
+}
+
+// Audience fetches the audiences of repos on GitHub in order, the cache is not
+// used.
+func (c *Client) Audience(ctx context.Context, repos ...string) ([]Audience, error) {
+	if c.github == nil {
+		return nil, ErrMissingToken
+	}
+
+	list := stat.FetchAudience(ctx, c.config(), repos...)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	resolved := make(map[string]struct{}, len(list))
+	for _, e := range list {
+		resolved[e.FullName] = struct{}{}
+	}
+	var missing []string
+	for _, e := range repos {
+		if _, ok := resolved[e]; !ok {
+			missing = append(missing, e)
+		}
+	}
+	if len(missing) > 0 {
+		return list, fmt.Errorf("could not resolve %s", strings.Join(missing, ", "))
+	}
+
+	return list, nil
+}
+
+// Windows returns the periods which the results are fetched with.
+func (c *Client) Windows() Windows {
+	return c.windows
+}
+
+func (c *Client) config() stat.Config {
+	return stat.Config{
+		Client:      c.github,
+		Transport:   c.transport,
+		Windows:     c.windows,
+		Detail:      c.detail,
+		GitLabToken: c.gitlabToken,
+		GiteaToken:  c.giteaToken,
+	}
+}
@@ -0,0 +1,107 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package compare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/anqiansong/github-compare/pkg/stat"
+)
+
+func TestFetchMissingToken(t *testing.T) {
+	t.Setenv("GITHUB_ACCESS_TOKEN", "")
+
+	_, err := NewClient().Fetch(context.Background(), "owner/repo")
+	if !errors.Is(err, ErrMissingToken) {
+		t.Fatalf("expected missing token, got %v", err)
+	}
+}
+
+func TestFetchCache(t *testing.T) {
+	var requests int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/repos/owner/repo", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprint(w, `{"stars_count":50,"forks_count":5,"created_at":"2021-01-01T00:00:00Z"}`)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+	svr := httptest.NewServer(mux)
+	defer svr.Close()
+
+	var (
+		repo   = stat.GiteaPrefix + svr.URL + "/owner/repo"
+		client = NewClient(WithCache(NewMemoryCache(time.Minute)), WithConcurrency(2))
+		done   int32
+	)
+	ctx := WithProgress(context.Background(), func(e Event) {
+		if e.Done && e.Err == nil {
+			atomic.AddInt32(&done, 1)
+		}
+	})
+
+	for i := 0; i < 2; i++ {
+		results, err := client.Fetch(ctx, repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(results) != 1 || results[0].FullName != repo || results[0].StarCount != 50 {
+			t.Fatalf("unexpected results: %+v", results)
+		}
+	}
+	if requests != 1 || done != 1 {
+		t.Fatalf("expected 1 request and 1 event, got %d and %d", requests, done)
+	}
+	if w := client.Windows(); w != stat.DefaultWindows() {
+		t.Fatalf("expected default windows, got %+v", w)
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	var (
+		a = NewClient(WithWindows(Windows{History: 90 * 24 * time.Hour}))
+		b = NewClient(WithWindows(Windows{Lifecycle: 30 * 24 * time.Hour}))
+		c = NewClient()
+	)
+	if a.cacheKey("owner/repo") == c.cacheKey("owner/repo") ||
+		b.cacheKey("owner/repo") == c.cacheKey("owner/repo") {
+		t.Fatal("expected the windows to tell the cache keys apart")
+	}
+}
+
+func TestMemoryCacheExpire(t *testing.T) {
+	c := NewMemoryCache(time.Millisecond)
+	c.Set("key", Result{FullName: "owner/repo"})
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := c.Get("key"); ok {
+		t.Fatal("expected the entry to expire")
+	}
+}
@@ -0,0 +1,238 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package deps
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	EcosystemGo     = "go"
+	EcosystemNPM    = "npm"
+	EcosystemPyPI   = "pypi"
+	EcosystemCrates = "crates"
+)
+
+// Dependency is a dependency declared in a manifest, Source is the github
+// repository url declared by the manifest directly, e.g. a git dependency.
+// Indirect marks the transitive dependencies and Dev marks the dependencies
+// which are only used for development or building.
+type Dependency struct {
+	Name      string
+	Version   string
+	Ecosystem string
+	Indirect  bool
+	Dev       bool
+	Source    string
+}
+
+var requirementNameRegex = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)`)
+
+// ParseFile parses the manifest by its file name, supported manifests are
+// go.mod, package.json, requirements*.txt and Cargo.toml.
+func ParseFile(file string) ([]Dependency, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	base := strings.ToLower(filepath.Base(file))
+	switch {
+	case base == "go.mod":
+		return ParseGoMod(data)
+	case base == "package.json":
+		return ParsePackageJSON(data)
+	case base == "cargo.toml":
+		return ParseCargoToml(data)
+	case strings.HasPrefix(base, "requirements") && filepath.Ext(base) == ".txt":
+		return ParseRequirements(data)
+	default:
+		return nil, fmt.Errorf("unsupported manifest %q", file)
+	}
+}
+
+func ParseGoMod(data []byte) ([]Dependency, error) {
+	var (
+		list    []Dependency
+		inBlock bool
+		scanner = bufio.NewScanner(bytes.NewReader(data))
+	)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case line == "require (":
+			inBlock = true
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "require"))
+		case !inBlock:
+			continue
+		}
+
+		indirect := strings.HasSuffix(line, "// indirect")
+		if idx := strings.Index(line, "//"); idx >= 0 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		list = append(list, Dependency{
+			Name:      fields[0],
+			Version:   fields[1],
+			Ecosystem: EcosystemGo,
+			Indirect:  indirect,
+		})
+	}
+
+	return list, scanner.Err()
+}
+
+func ParsePackageJSON(data []byte) ([]Dependency, error) {
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, err
+	}
+
+	var list []Dependency
+	for _, m := range []struct {
+		deps map[string]string
+		dev  bool
+	}{{pkg.Dependencies, false}, {pkg.DevDependencies, true}} {
+		for name, version := range m.deps {
+			d := Dependency{Name: name, Version: version, Ecosystem: EcosystemNPM, Dev: m.dev}
+			if isGithubSpec(version) {
+				d.Source = version
+			}
+			list = append(list, d)
+		}
+	}
+
+	sortDependencies(list)
+	return list, nil
+}
+
+// isGithubSpec reports whether an npm version is a github reference, e.g.
+// github:owner/repo, owner/repo#tag or git+https://github.com/owner/repo.git.
+func isGithubSpec(version string) bool {
+	if strings.HasPrefix(version, "github:") || strings.Contains(version, "github.com") {
+		return true
+	}
+	_, ok := githubRepo(version)
+	return ok && !strings.ContainsAny(version, ":@ ")
+}
+
+func ParseRequirements(data []byte) ([]Dependency, error) {
+	var (
+		list    []Dependency
+		scanner = bufio.NewScanner(bytes.NewReader(data))
+	)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if idx := strings.Index(line, " #"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		if len(line) == 0 || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+
+		if strings.Contains(line, "github.com") {
+			name := line
+			if idx := strings.Index(line, "#egg="); idx >= 0 {
+				name = line[idx+len("#egg="):]
+			}
+			list = append(list, Dependency{Name: name, Ecosystem: EcosystemPyPI, Source: line})
+			continue
+		}
+
+		if idx := strings.Index(line, ";"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		name := requirementNameRegex.FindString(line)
+		if len(name) == 0 {
+			continue
+		}
+
+		version := strings.TrimSpace(strings.TrimPrefix(line, name))
+		if strings.HasPrefix(version, "[") {
+			if idx := strings.Index(version, "]"); idx >= 0 {
+				version = strings.TrimSpace(version[idx+1:])
+			}
+		}
+		list = append(list, Dependency{Name: name, Version: version, Ecosystem: EcosystemPyPI})
+	}
+
+	return list, scanner.Err()
+}
+
+func ParseCargoToml(data []byte) ([]Dependency, error) {
+	v := viper.New()
+	v.SetConfigType("toml")
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+
+	var list []Dependency
+	for _, section := range []struct {
+		key string
+		dev bool
+	}{{"dependencies", false}, {"dev-dependencies", true}, {"build-dependencies", true}} {
+		for name, spec := range v.GetStringMap(section.key) {
+			d := Dependency{Name: name, Ecosystem: EcosystemCrates, Dev: section.dev}
+			switch s := spec.(type) {
+			case string:
+				d.Version = s
+			case map[string]interface{}:
+				d.Version = fmt.Sprint(s["version"])
+				if pkg, ok := s["package"].(string); ok {
+					d.Name = pkg
+				}
+				if git, ok := s["git"].(string); ok {
+					d.Source = git
+				}
+			}
+			list = append(list, d)
+		}
+	}
+
+	sortDependencies(list)
+	return list, nil
+}
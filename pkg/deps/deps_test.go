@@ -0,0 +1,164 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package deps
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseGoMod(t *testing.T) {
+	list, err := ParseGoMod([]byte(`module example.com/foo
+
+go 1.18
+
+require github.com/spf13/cobra v1.4.0
+
+require (
+	gopkg.in/yaml.v3 v3.0.0 // indirect
+	golang.org/x/net v0.0.0-20220520000938-2e3eb7b945c2
+)
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Dependency{
+		{Name: "github.com/spf13/cobra", Version: "v1.4.0", Ecosystem: EcosystemGo},
+		{Name: "gopkg.in/yaml.v3", Version: "v3.0.0", Ecosystem: EcosystemGo, Indirect: true},
+		{Name: "golang.org/x/net", Version: "v0.0.0-20220520000938-2e3eb7b945c2",
+			Ecosystem: EcosystemGo},
+	}
+	if !reflect.DeepEqual(list, expected) {
+		t.Fatalf("unexpected dependencies: %+v", list)
+	}
+}
+
+func TestParseManifests(t *testing.T) {
+	list, err := ParsePackageJSON([]byte(`{
+  "dependencies": {"react": "^18.0.0", "fx": "github:antonmedv/fx"},
+  "devDependencies": {"jest": "^28.0.0"}
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 3 || list[0].Name != "fx" || list[0].Source == "" || !list[2].Dev ||
+		list[2].Indirect {
+		t.Fatalf("unexpected package.json dependencies: %+v", list)
+	}
+
+	list, err = ParseRequirements([]byte(`# comment
+requests[socks]>=2.0 ; python_version > "3.6"
+-r other.txt
+git+https://github.com/psf/black@22.3.0#egg=black
+flask
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 3 || list[0].Name != "requests" || list[0].Version != ">=2.0" ||
+		list[1].Name != "black" || list[2].Name != "flask" {
+		t.Fatalf("unexpected requirements: %+v", list)
+	}
+
+	list, err = ParseCargoToml([]byte(`[package]
+name = "foo"
+
+[dependencies]
+serde = "1.0"
+tokio = { version = "1", features = ["full"] }
+clap = { git = "https://github.com/clap-rs/clap" }
+
+[dev-dependencies]
+criterion = "0.3"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 4 || list[0].Name != "clap" || list[0].Source == "" ||
+		list[2].Version != "1" || list[3].Name != "criterion" || !list[3].Dev {
+		t.Fatalf("unexpected cargo dependencies: %+v", list)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/go.uber.org/zap", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<meta name="go-import" content="go.uber.org/zap git https://github.com/uber-go/zap">`)
+	})
+	mux.HandleFunc("/npm/react", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"repository":{"type":"git","url":"git+https://github.com/facebook/react.git"}}`)
+	})
+	mux.HandleFunc("/pypi/flask/json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"info":{"home_page":"https://palletsprojects.com/p/flask","project_urls":{"Documentation":"https://flask.palletsprojects.com/","Source Code":"https://github.com/pallets/flask/"}}}`)
+	})
+	mux.HandleFunc("/crates/serde", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"crate":{"repository":"https://github.com/serde-rs/serde"}}`)
+	})
+	svr := httptest.NewServer(mux)
+	defer svr.Close()
+
+	r := NewResolver()
+	r.GoGet = svr.URL + "/"
+	r.NPMRegistry = svr.URL + "/npm"
+	r.PyPI = svr.URL + "/pypi"
+	r.Crates = svr.URL + "/crates"
+
+	for _, e := range []struct {
+		dep      Dependency
+		expected string
+	}{
+		{Dependency{Name: "github.com/spf13/cobra/doc", Ecosystem: EcosystemGo}, "spf13/cobra"},
+		{Dependency{Name: "gopkg.in/yaml.v3", Ecosystem: EcosystemGo}, "go-yaml/yaml"},
+		{Dependency{Name: "golang.org/x/net", Ecosystem: EcosystemGo}, "golang/net"},
+		{Dependency{Name: "go.uber.org/zap", Ecosystem: EcosystemGo}, "uber-go/zap"},
+		{Dependency{Name: "react", Ecosystem: EcosystemNPM}, "facebook/react"},
+		{Dependency{Name: "fx", Ecosystem: EcosystemNPM, Source: "github:antonmedv/fx"},
+			"antonmedv/fx"},
+		{Dependency{Name: "flask", Ecosystem: EcosystemPyPI}, "pallets/flask"},
+		{Dependency{Name: "serde", Ecosystem: EcosystemCrates}, "serde-rs/serde"},
+	} {
+		repo, err := r.Resolve(context.Background(), e.dep)
+		if err != nil {
+			t.Fatalf("resolve %s: %v", e.dep.Name, err)
+		}
+		if repo != e.expected {
+			t.Fatalf("resolve %s: expected %s, got %s", e.dep.Name, e.expected, repo)
+		}
+	}
+
+	ctx := context.Background()
+	if _, err := r.Resolve(ctx, Dependency{Name: "unknown", Ecosystem: EcosystemNPM}); err == nil {
+		t.Fatal("expected error for unknown package")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := r.Resolve(ctx, Dependency{Name: "react", Ecosystem: EcosystemNPM}); err == nil {
+		t.Fatal("expected error for canceled context")
+	}
+}
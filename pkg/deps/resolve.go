@@ -0,0 +1,259 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package deps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const userAgent = "github-compare"
+
+var (
+	githubURLRegex   = regexp.MustCompile(`github\.com[/:]([\w.-]+)/([\w.-]+?)(?:\.git)?(?:[/#@?].*)?$`)
+	githubShortRegex = regexp.MustCompile(`^([\w-]+)/([\w.-]+?)(?:#.*)?$`)
+	goImportRegex    = regexp.MustCompile(`<meta\s+name=["']go-import["']\s+content=["']([^"']+)["']`)
+	gopkgRegex       = regexp.MustCompile(`^gopkg\.in/(?:([\w-]+)/)?([\w-]+)\.v\d+`)
+)
+
+// Resolver maps dependencies to github repositories, it looks up the package
+// registries for the dependencies which are not declared by github urls.
+type Resolver struct {
+	Client      *http.Client
+	GoGet       string
+	NPMRegistry string
+	PyPI        string
+	Crates      string
+}
+
+func NewResolver() *Resolver {
+	return &Resolver{
+		Client:      &http.Client{Timeout: 10 * time.Second},
+		GoGet:       "https://",
+		NPMRegistry: "https://registry.npmjs.org",
+		PyPI:        "https://pypi.org/pypi",
+		Crates:      "https://crates.io/api/v1/crates",
+	}
+}
+
+// Resolve returns the github repository of d in owner/repo format.
+func (r *Resolver) Resolve(ctx context.Context, d Dependency) (string, error) {
+	if len(d.Source) > 0 {
+		if repo, ok := githubRepo(d.Source); ok {
+			return repo, nil
+		}
+	}
+
+	var (
+		repo string
+		err  error
+	)
+	switch d.Ecosystem {
+	case EcosystemGo:
+		repo, err = r.resolveGo(ctx, d.Name)
+	case EcosystemNPM:
+		repo, err = r.resolveNPM(ctx, d.Name)
+	case EcosystemPyPI:
+		repo, err = r.resolvePyPI(ctx, d.Name)
+	case EcosystemCrates:
+		repo, err = r.resolveCrates(ctx, d.Name)
+	default:
+		err = fmt.Errorf("unknown ecosystem %q", d.Ecosystem)
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(repo) == 0 {
+		return "", fmt.Errorf("%s is not hosted on github", d.Name)
+	}
+
+	return repo, nil
+}
+
+func (r *Resolver) resolveGo(ctx context.Context, module string) (string, error) {
+	switch {
+	case strings.HasPrefix(module, "github.com/"):
+		repo, _ := githubRepo(module)
+		return repo, nil
+	case strings.HasPrefix(module, "golang.org/x/"):
+		name := strings.Split(strings.TrimPrefix(module, "golang.org/x/"), "/")[0]
+		return "golang/" + name, nil
+	case strings.HasPrefix(module, "gopkg.in/"):
+		match := gopkgRegex.FindStringSubmatch(module)
+		if len(match) != 3 {
+			return "", fmt.Errorf("invalid module %q", module)
+		}
+		owner := match[1]
+		if len(owner) == 0 {
+			owner = "go-" + match[2]
+		}
+		return owner + "/" + match[2], nil
+	}
+
+	body, err := r.get(ctx, r.GoGet+module+"?go-get=1")
+	if err != nil {
+		return "", err
+	}
+
+	for _, match := range goImportRegex.FindAllStringSubmatch(string(body), -1) {
+		fields := strings.Fields(match[1])
+		if len(fields) == 3 && strings.HasPrefix(module, fields[0]) {
+			repo, _ := githubRepo(fields[2])
+			return repo, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (r *Resolver) resolveNPM(ctx context.Context, name string) (string, error) {
+	var pkg struct {
+		Repository json.RawMessage `json:"repository"`
+	}
+	if err := r.getJSON(ctx, r.NPMRegistry+"/"+strings.Replace(url.PathEscape(name), "%40", "@", 1),
+		&pkg); err != nil {
+		return "", err
+	}
+
+	var repository struct {
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(pkg.Repository, &repository); err != nil {
+		// the repository field can be a plain string
+		var s string
+		_ = json.Unmarshal(pkg.Repository, &s)
+		repository.URL = s
+	}
+
+	repo, _ := githubRepo(repository.URL)
+	return repo, nil
+}
+
+func (r *Resolver) resolvePyPI(ctx context.Context, name string) (string, error) {
+	var pkg struct {
+		Info struct {
+			HomePage    string            `json:"home_page"`
+			ProjectURLs map[string]string `json:"project_urls"`
+		} `json:"info"`
+	}
+	if err := r.getJSON(ctx, r.PyPI+"/"+url.PathEscape(name)+"/json", &pkg); err != nil {
+		return "", err
+	}
+
+	candidates := []string{pkg.Info.HomePage}
+	var keys []string
+	for k := range pkg.Info.ProjectURLs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	// prefer the source links over documentation links
+	sort.SliceStable(keys, func(i, j int) bool {
+		return strings.Contains(strings.ToLower(keys[i]), "source") &&
+			!strings.Contains(strings.ToLower(keys[j]), "source")
+	})
+	for _, k := range keys {
+		candidates = append(candidates, pkg.Info.ProjectURLs[k])
+	}
+
+	for _, e := range candidates {
+		if repo, ok := githubRepo(e); ok {
+			return repo, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (r *Resolver) resolveCrates(ctx context.Context, name string) (string, error) {
+	var pkg struct {
+		Crate struct {
+			Repository string `json:"repository"`
+		} `json:"crate"`
+	}
+	if err := r.getJSON(ctx, r.Crates+"/"+url.PathEscape(name), &pkg); err != nil {
+		return "", err
+	}
+
+	repo, _ := githubRepo(pkg.Crate.Repository)
+	return repo, nil
+}
+
+func (r *Resolver) getJSON(ctx context.Context, u string, v interface{}) error {
+	body, err := r.get(ctx, u)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+func (r *Resolver) get(ctx context.Context, u string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", u, resp.Status)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// githubRepo extracts owner/repo from a github url or reference.
+func githubRepo(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if match := githubURLRegex.FindStringSubmatch(s); len(match) == 3 {
+		return match[1] + "/" + match[2], true
+	}
+
+	s = strings.TrimPrefix(s, "github:")
+	if match := githubShortRegex.FindStringSubmatch(s); len(match) == 3 {
+		return match[1] + "/" + match[2], true
+	}
+
+	return "", false
+}
+
+func sortDependencies(list []Dependency) {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Dev != list[j].Dev {
+			return !list[i].Dev
+		}
+		return list[i].Name < list[j].Name
+	})
+}
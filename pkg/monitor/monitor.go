@@ -0,0 +1,253 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package monitor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anqiansong/github-compare/pkg/check"
+	"github.com/anqiansong/github-compare/pkg/stat"
+	"gopkg.in/yaml.v3"
+)
+
+var deltaRegex = regexp.MustCompile(`^\s*(\w+)\.delta\s*(<=|>=|==|!=|<|>)\s*(.+?)\s*$`)
+
+type (
+	// Rule is a rule of check, or a rule of the change of a metric since the
+	// last run like `stars.delta >= 500`.
+	Rule struct {
+		check.Rule
+		Delta bool
+	}
+
+	// Alert is a rule which starts to fire for a repository.
+	Alert struct {
+		Repo   string    `json:"repo"`
+		Rule   string    `json:"rule"`
+		Actual string    `json:"actual"`
+		At     time.Time `json:"at"`
+	}
+
+	// State is what the last run saw, it's kept in a file between the runs.
+	State struct {
+		Repos map[string]RepoState `json:"repos"`
+	}
+
+	RepoState struct {
+		CheckedAt time.Time `json:"checkedAt"`
+		// Values are the metrics of the delta rules.
+		Values map[string]float64 `json:"values,omitempty"`
+		// Firing are the rules which fired in the last run.
+		Firing []string `json:"firing,omitempty"`
+	}
+
+	ruleFile struct {
+		Rules []string `yaml:"rules"`
+	}
+)
+
+// LoadRules reads the rules in the format of check.LoadRules.
+func LoadRules(file string) ([]Rule, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var f ruleFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	if len(f.Rules) == 0 {
+		return nil, fmt.Errorf("no rules found in %s", file)
+	}
+
+	var rules []Rule
+	for _, e := range f.Rules {
+		r, err := ParseRule(e)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+
+	return rules, nil
+}
+
+func ParseRule(expr string) (Rule, error) {
+	match := deltaRegex.FindStringSubmatch(expr)
+	if len(match) != 4 {
+		r, err := check.ParseRule(expr)
+		return Rule{Rule: r}, err
+	}
+
+	r := check.Rule{Expr: strings.TrimSpace(expr), Field: match[1], Op: match[2],
+		Value: strings.Trim(match[3], `"'`)}
+	if err := stat.CheckSortKey(r.Field); err != nil {
+		return Rule{}, fmt.Errorf("invalid rule %q: unknown field %q", expr, r.Field)
+	}
+	if _, err := strconv.ParseFloat(r.Value, 64); err != nil {
+		return Rule{}, fmt.Errorf("invalid rule %q: %q is not a number", expr, r.Value)
+	}
+
+	return Rule{Rule: r, Delta: true}, nil
+}
+
+// LoadState reads the state file, it's empty if the file doesn't exist.
+func LoadState(file string) (*State, error) {
+	s := &State{Repos: map[string]RepoState{}}
+	data, err := ioutil.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid state file %s: %w", file, err)
+	}
+	if s.Repos == nil {
+		s.Repos = map[string]RepoState{}
+	}
+	return s, nil
+}
+
+// Save writes the state to a temporary file and renames it to file, so that a
+// run killed halfway never leaves a broken state.
+func (s *State) Save(file string) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	// keep the rules readable
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(s); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(file), filepath.Base(file)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// Evaluate checks the rules against list and returns the alerts with the next
+// state, state is left untouched so that it's kept until the alerts are posted.
+// A rule alerts when it starts to fire, except that a delta rule alerts
+// whenever it fires since every run has a new change. A delta rule never fires
+// in the first run of a repository.
+func Evaluate(rules []Rule, list []stat.Data, state *State, now time.Time) ([]Alert, *State) {
+	var (
+		alerts []Alert
+		ret    = &State{Repos: make(map[string]RepoState, len(state.Repos))}
+	)
+	for k, v := range state.Repos {
+		ret.Repos[k] = v
+	}
+
+	for _, d := range list {
+		var (
+			last   = state.Repos[d.FullName]
+			next   = RepoState{CheckedAt: now, Values: map[string]float64{}}
+			firing = map[string]struct{}{}
+		)
+		for _, e := range last.Firing {
+			firing[e] = struct{}{}
+		}
+
+		for _, r := range rules {
+			passed, actual := r.eval(d, last, next.Values)
+			if !passed {
+				continue
+			}
+
+			next.Firing = append(next.Firing, r.Expr)
+			if _, ok := firing[r.Expr]; ok && !r.Delta {
+				continue
+			}
+			alerts = append(alerts, Alert{Repo: d.FullName, Rule: r.Expr, Actual: actual, At: now})
+		}
+
+		sort.Strings(next.Firing)
+		ret.Repos[d.FullName] = next
+	}
+
+	return alerts, ret
+}
+
+// eval checks the rule against d, the values of the delta rules are recorded
+// to values.
+func (r Rule) eval(d stat.Data, last RepoState, values map[string]float64) (bool, string) {
+	if !r.Delta {
+		passed, actual, err := r.Eval(d)
+		return passed && err == nil, actual
+	}
+
+	actual, ok := stat.Value(d, r.Field)
+	if !ok || math.IsInf(actual, 0) || math.IsNaN(actual) {
+		// keep the last value for the next run
+		if v, ok := last.Values[r.Field]; ok {
+			values[r.Field] = v
+		}
+		return false, ""
+	}
+	values[r.Field] = actual
+
+	previous, ok := last.Values[r.Field]
+	if !ok {
+		return false, ""
+	}
+	expected, err := strconv.ParseFloat(r.Value, 64)
+	if err != nil {
+		return false, ""
+	}
+
+	delta := actual - previous
+	return check.Compare(delta, r.Op, expected), fmt.Sprintf("%+g since %s", delta,
+		last.CheckedAt.Format(time.RFC3339))
+}
+
+func (a Alert) String() string {
+	return fmt.Sprintf("%s: %s (%s)", a.Repo, a.Rule, a.Actual)
+}
@@ -0,0 +1,116 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package monitor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/anqiansong/github-compare/pkg/stat"
+)
+
+func TestParseRule(t *testing.T) {
+	r, err := ParseRule("stars.delta >= 500")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Delta || r.Field != "stars" || r.Op != ">=" || r.Value != "500" {
+		t.Fatalf("unexpected rule: %+v", r)
+	}
+	if r, err := ParseRule("lastPush > 180d"); err != nil || r.Delta {
+		t.Fatalf("unexpected rule: %+v, %v", r, err)
+	}
+
+	for _, e := range []string{"unknown.delta > 1", "stars.delta > many", "lastPush.delta > 1d"} {
+		if _, err := ParseRule(e); err == nil {
+			t.Fatalf("expected error of %q", e)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	var rules []Rule
+	for _, e := range []string{"stars.delta >= 500", "lastPush > 180d"} {
+		r, err := ParseRule(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules = append(rules, r)
+	}
+
+	var (
+		now   = time.Now()
+		state = &State{Repos: map[string]RepoState{}}
+		stale = now.Add(-200 * 24 * time.Hour)
+		data  = func(stars int) []stat.Data {
+			return []stat.Data{{FullName: "a/b",
+				Metrics: stat.Metrics{StarCount: stars, PushedAt: stale}}}
+		}
+	)
+
+	// the first run has no change of stars
+	alerts, next := Evaluate(rules, data(1000), state, now)
+	if len(alerts) != 1 || alerts[0].Rule != "lastPush > 180d" {
+		t.Fatalf("unexpected alerts: %+v", alerts)
+	}
+	if len(state.Repos) != 0 {
+		t.Fatalf("state is changed: %+v", state)
+	}
+	state = next
+	if v := state.Repos["a/b"].Values["stars"]; v != 1000 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+
+	// lastPush keeps firing, it alerts only once
+	alerts, state = Evaluate(rules, data(1600), state, now.Add(24*time.Hour))
+	if len(alerts) != 1 || alerts[0].Rule != "stars.delta >= 500" || alerts[0].Actual[:4] != "+600" {
+		t.Fatalf("unexpected alerts: %+v", alerts)
+	}
+	if len(state.Repos["a/b"].Firing) != 2 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+
+	alerts, _ = Evaluate(rules, data(1700), state, now.Add(48*time.Hour))
+	if len(alerts) != 0 {
+		t.Fatalf("unexpected alerts: %+v", alerts)
+	}
+}
+
+func TestState(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "state.json")
+	s, err := LoadState(file)
+	if err != nil || len(s.Repos) != 0 {
+		t.Fatalf("unexpected state: %+v, %v", s, err)
+	}
+
+	s.Repos["a/b"] = RepoState{Values: map[string]float64{"stars": 10}, Firing: []string{"stars > 5"}}
+	if err := s.Save(file); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = LoadState(file)
+	if err != nil || s.Repos["a/b"].Values["stars"] != 10 || s.Repos["a/b"].Firing[0] != "stars > 5" {
+		t.Fatalf("unexpected state: %+v, %v", s, err)
+	}
+}
@@ -0,0 +1,140 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	PayloadGeneric = "generic"
+	PayloadSlack   = "slack"
+	PayloadMatrix  = "matrix"
+)
+
+// Webhook posts the alerts to URL in the format of Payload. The URL of matrix
+// is https://<homeserver>/_matrix/client/v3/rooms/<room id>/send/m.room.message
+// with the access token in the query, the events are sent by PUT to it with a
+// transaction id appended.
+type Webhook struct {
+	URL     string
+	Payload string
+	Client  *http.Client
+}
+
+// CheckPayload returns an error if the payload format is not supported.
+func CheckPayload(payload string) error {
+	switch payload {
+	case PayloadGeneric, PayloadSlack, PayloadMatrix:
+		return nil
+	default:
+		return fmt.Errorf("invalid payload %q, expected one of: %s, %s, %s", payload,
+			PayloadGeneric, PayloadSlack, PayloadMatrix)
+	}
+}
+
+// Notify posts the alerts, a response out of 2xx is an error.
+func (w Webhook) Notify(ctx context.Context, alerts []Alert) error {
+	body, err := w.body(alerts)
+	if err != nil {
+		return err
+	}
+
+	method, target, err := w.target()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded %s", w.URL, resp.Status)
+	}
+	return nil
+}
+
+// target returns the method and the url of the request.
+func (w Webhook) target() (string, string, error) {
+	if w.Payload != PayloadMatrix {
+		return http.MethodPost, w.URL, nil
+	}
+
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return "", "", err
+	}
+	// the homeserver drops the events of a transaction id it has seen
+	txnID := fmt.Sprintf("github-compare-%d", time.Now().UnixNano())
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + txnID
+	if len(u.RawPath) > 0 {
+		u.RawPath = strings.TrimSuffix(u.RawPath, "/") + "/" + txnID
+	}
+	return http.MethodPut, u.String(), nil
+}
+
+// body builds the payload, generic is the alerts as they are, slack is an
+// incoming webhook message and matrix is the content of an m.room.message
+// event.
+func (w Webhook) body(alerts []Alert) ([]byte, error) {
+	switch w.Payload {
+	case PayloadGeneric, "":
+		return json.Marshal(map[string]interface{}{"alerts": alerts})
+	case PayloadSlack:
+		return json.Marshal(map[string]string{"text": text(alerts)})
+	case PayloadMatrix:
+		return json.Marshal(map[string]string{"msgtype": "m.text", "body": text(alerts)})
+	default:
+		return nil, CheckPayload(w.Payload)
+	}
+}
+
+func text(alerts []Alert) string {
+	lines := []string{fmt.Sprintf("github-compare: %d alert(s)", len(alerts))}
+	for _, e := range alerts {
+		lines = append(lines, "• "+e.String())
+	}
+	return strings.Join(lines, "\n")
+}
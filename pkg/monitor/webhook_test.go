@@ -0,0 +1,102 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookNotify(t *testing.T) {
+	const matrixPath = "/_matrix/client/v3/rooms/!abc:example.org/send/m.room.message/"
+	var received map[string]interface{}
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := http.MethodPost
+		if strings.HasPrefix(r.URL.Path, matrixPath) {
+			method = http.MethodPut
+		}
+		if r.Method != method || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = nil
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer svr.Close()
+
+	alerts := []Alert{{Repo: "a/b", Rule: "stars.delta >= 500", Actual: "+600", At: time.Now()}}
+	for _, e := range []struct {
+		payload string
+		path    string
+		key     string
+		expect  string
+	}{
+		{payload: PayloadSlack, key: "text", expect: "• a/b: stars.delta >= 500 (+600)"},
+		{payload: PayloadMatrix,
+			path: "/_matrix/client/v3/rooms/%21abc%3Aexample.org/send/m.room.message?access_token=x",
+			key:  "body", expect: "• a/b: stars.delta >= 500 (+600)"},
+	} {
+		w := Webhook{URL: svr.URL + e.path, Payload: e.payload}
+		if err := w.Notify(context.Background(), alerts); err != nil {
+			t.Fatal(err)
+		}
+		text, _ := received[e.key].(string)
+		if !strings.HasSuffix(text, e.expect) {
+			t.Fatalf("unexpected %s payload: %+v", e.payload, received)
+		}
+	}
+	if received["msgtype"] != "m.text" {
+		t.Fatalf("unexpected matrix payload: %+v", received)
+	}
+
+	w := Webhook{URL: svr.URL, Payload: PayloadGeneric}
+	if err := w.Notify(context.Background(), alerts); err != nil {
+		t.Fatal(err)
+	}
+	list, _ := received["alerts"].([]interface{})
+	if len(list) != 1 || list[0].(map[string]interface{})["repo"] != "a/b" {
+		t.Fatalf("unexpected generic payload: %+v", received)
+	}
+}
+
+func TestWebhookError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer svr.Close()
+
+	w := Webhook{URL: svr.URL, Payload: PayloadSlack}
+	if err := w.Notify(context.Background(), []Alert{{Repo: "a/b"}}); err == nil {
+		t.Fatal("expected error of 403")
+	}
+	if err := CheckPayload("discord"); err == nil {
+		t.Fatal("expected error of discord")
+	}
+}
@@ -0,0 +1,184 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sched
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+type (
+	// Scheduler limits the requests in flight, the waiting requests are
+	// granted in round-robin order of their hosts so that a busy host can not
+	// starve the others.
+	Scheduler struct {
+		lock     sync.Mutex
+		max      int
+		inFlight int
+		queues   map[string][]*waiter
+		hosts    []string
+		next     int
+	}
+
+	waiter struct {
+		ready   chan struct{}
+		granted bool
+	}
+
+	transport struct {
+		scheduler *Scheduler
+		base      http.RoundTripper
+	}
+)
+
+// New returns a Scheduler which allows max requests in flight, max <= 0 means
+// unlimited.
+func New(max int) *Scheduler {
+	return &Scheduler{max: max, queues: make(map[string][]*waiter)}
+}
+
+// SetMax changes the limit of requests in flight.
+func (s *Scheduler) SetMax(max int) {
+	s.lock.Lock()
+	s.max = max
+	for s.unlimited() || s.inFlight < s.max {
+		if !s.grant() {
+			break
+		}
+		s.inFlight++
+	}
+	s.lock.Unlock()
+}
+
+// Acquire blocks until a request to host is allowed or ctx is done.
+func (s *Scheduler) Acquire(ctx context.Context, host string) error {
+	s.lock.Lock()
+	if s.unlimited() || (s.inFlight < s.max && len(s.hosts) == 0) {
+		s.inFlight++
+		s.lock.Unlock()
+		return nil
+	}
+
+	w := &waiter{ready: make(chan struct{})}
+	if _, ok := s.queues[host]; !ok {
+		s.hosts = append(s.hosts, host)
+	}
+	s.queues[host] = append(s.queues[host], w)
+	s.lock.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.lock.Lock()
+		if w.granted {
+			s.lock.Unlock()
+			s.Release()
+		} else {
+			s.remove(host, w)
+			s.lock.Unlock()
+		}
+		return ctx.Err()
+	}
+}
+
+// Release finishes a request and hands the slot to the next waiting one.
+func (s *Scheduler) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.grant() {
+		s.inFlight--
+	}
+}
+
+// Transport wraps base so that every request goes through the scheduler.
+func (s *Scheduler) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return transport{scheduler: s, base: base}
+}
+
+func (s *Scheduler) unlimited() bool {
+	return s.max <= 0
+}
+
+// grant hands the slot to the next waiter, it must be called with lock held.
+func (s *Scheduler) grant() bool {
+	if len(s.hosts) == 0 {
+		return false
+	}
+
+	s.next %= len(s.hosts)
+	host := s.hosts[s.next]
+	queue := s.queues[host]
+	w := queue[0]
+	if len(queue) == 1 {
+		delete(s.queues, host)
+		s.hosts = append(s.hosts[:s.next], s.hosts[s.next+1:]...)
+	} else {
+		s.queues[host] = queue[1:]
+		s.next++
+	}
+
+	w.granted = true
+	close(w.ready)
+	return true
+}
+
+// remove drops a waiter which gives up, it must be called with lock held.
+func (s *Scheduler) remove(host string, w *waiter) {
+	queue := s.queues[host]
+	for i, e := range queue {
+		if e == w {
+			queue = append(queue[:i], queue[i+1:]...)
+			break
+		}
+	}
+	if len(queue) > 0 {
+		s.queues[host] = queue
+		return
+	}
+
+	delete(s.queues, host)
+	for i, e := range s.hosts {
+		if e == host {
+			s.hosts = append(s.hosts[:i], s.hosts[i+1:]...)
+			if s.next > i {
+				s.next--
+			}
+			break
+		}
+	}
+}
+
+func (t transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.scheduler.Acquire(req.Context(), req.URL.Host); err != nil {
+		return nil, err
+	}
+	defer t.scheduler.Release()
+
+	return t.base.RoundTrip(req)
+}
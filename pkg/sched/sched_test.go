@@ -0,0 +1,135 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sched
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSchedulerLimit(t *testing.T) {
+	var (
+		inFlight, peak int32
+		release        = make(chan struct{})
+	)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		<-release
+		atomic.AddInt32(&inFlight, -1)
+	}))
+	defer svr.Close()
+
+	client := &http.Client{Transport: New(2).Transport(nil)}
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(svr.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if peak != 2 {
+		t.Fatalf("expected 2 requests in flight at most, got %d", peak)
+	}
+}
+
+func TestSchedulerFairness(t *testing.T) {
+	s := New(1)
+	ctx := context.Background()
+	if err := s.Acquire(ctx, "busy"); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		order []string
+		lock  sync.Mutex
+		wg    sync.WaitGroup
+	)
+	enqueue := func(host string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.Acquire(ctx, host); err != nil {
+				t.Error(err)
+				return
+			}
+			lock.Lock()
+			order = append(order, host)
+			lock.Unlock()
+			s.Release()
+		}()
+		// make sure the waiters are queued in order
+		time.Sleep(10 * time.Millisecond)
+	}
+	enqueue("busy")
+	enqueue("busy")
+	enqueue("busy")
+	enqueue("quiet")
+
+	s.Release()
+	wg.Wait()
+
+	expected := []string{"busy", "quiet", "busy", "busy"}
+	for i, e := range expected {
+		if order[i] != e {
+			t.Fatalf("expected %v, got %v", expected, order)
+		}
+	}
+}
+
+func TestSchedulerCancel(t *testing.T) {
+	s := New(1)
+	if err := s.Acquire(context.Background(), "host"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := s.Acquire(ctx, "host"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+
+	s.Release()
+	if err := s.Acquire(context.Background(), "host"); err != nil {
+		t.Fatal(err)
+	}
+}
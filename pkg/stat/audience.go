@@ -0,0 +1,188 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"context"
+	"time"
+
+	"github.com/kevwan/mapreduce/v2"
+	"github.com/shurcooL/githubv4"
+)
+
+// maxAudiencePages limits the stargazers of the audience to the latest 5000.
+const maxAudiencePages = 50
+
+type (
+	// Audience is the stargazers within Windows.Stars and the commit authors
+	// within Windows.History of a repository, an author is a login or an
+	// email if the commit isn't linked to a user. Stargazers and Contributors
+	// are nil if they are unknown.
+	Audience struct {
+		FullName     string   `json:"fullName"`
+		Stargazers   []string `json:"stargazers"`
+		Contributors []string `json:"contributors"`
+	}
+
+	StargazerLoginEdge struct {
+		StarredAt githubv4.DateTime
+		Node      Actor
+	}
+
+	StargazerLoginQuery struct {
+		Repository struct {
+			Stargazers struct {
+				Edges    []StargazerLoginEdge
+				PageInfo PageInfo
+			} `graphql:"stargazers(first: 100, orderBy: $orderBy, after: $after)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+)
+
+// FetchAudience fetches the audiences of repos on GitHub in order, the
+// repositories which can not be resolved are absent from the result.
+func FetchAudience(ctx context.Context, config Config, repos ...string) []Audience {
+	var (
+		windows                   = config.windows()
+		graphqlClient, restClient = newClients(config.Client)
+		repositories              = Repositories(ctx, graphqlClient, repos...)
+		list                      = make([]Audience, len(repos))
+	)
+
+	mapreduce.ForEach(func(source chan<- int) {
+		for i, r := range repos {
+			if _, ok := repositories[r]; ok {
+				source <- i
+			}
+		}
+	}, func(i int) {
+		s := newStat(ctx, repos[i], graphqlClient, restClient, windows)
+		list[i] = s.audience()
+	}, mapreduce.WithWorkers(len(repos)), mapreduce.WithContext(ctx))
+
+	var ret []Audience
+	for _, e := range list {
+		if len(e.FullName) > 0 {
+			ret = append(ret, e)
+		}
+	}
+	return ret
+}
+
+func (s Stat) audience() Audience {
+	ret := Audience{FullName: s.fullName()}
+	mapreduce.FinishVoid(func() {
+		ret.Stargazers = s.stargazerLogins()
+	}, func() {
+		history, _ := s.history()
+		if history == nil {
+			return
+		}
+
+		seen := make(map[string]struct{})
+		ret.Contributors = []string{}
+		for _, e := range history {
+			if _, ok := seen[e.Author]; !ok && len(e.Author) > 0 {
+				seen[e.Author] = struct{}{}
+				ret.Contributors = append(ret.Contributors, e.Author)
+			}
+		}
+	})
+	return ret
+}
+
+// stargazerLogins fetches the logins of the stargazers within Windows.Stars,
+// it's nil if the query fails.
+func (s Stat) stargazerLogins() []string {
+	var (
+		query    StargazerLoginQuery
+		list     = []string{}
+		deadline = time.Now().Add(-s.windows.Stars)
+	)
+
+	arg := map[string]interface{}{
+		"after": (*githubv4.String)(nil),
+		"owner": githubv4.String(s.owner),
+		"name":  githubv4.String(s.repo),
+		"orderBy": githubv4.StarOrder{
+			Field:     githubv4.StarOrderFieldStarredAt,
+			Direction: githubv4.OrderDirectionDesc,
+		},
+	}
+
+	for page := 1; page <= maxAudiencePages; page++ {
+		s.reportPage(StageStargazers, page)
+		if err := s.graphqlClient.Query(s.ctx, &query, arg); err != nil {
+			s.reportDone(StageStargazers, err)
+			return nil
+		}
+
+		conn := query.Repository.Stargazers
+		for _, e := range conn.Edges {
+			if e.StarredAt.Time.Before(deadline) {
+				s.reportDone(StageStargazers, nil)
+				return list
+			}
+			list = append(list, string(e.Node.Login))
+		}
+		if !(bool)(conn.PageInfo.HasNextPage) {
+			break
+		}
+
+		arg["after"] = githubv4.NewString(conn.PageInfo.EndCursor)
+	}
+
+	s.reportDone(StageStargazers, nil)
+	return list
+}
+
+// Jaccard returns the number of the items shared by a and b and the Jaccard
+// index of them, which is 0 if both are empty.
+func Jaccard(a, b []string) (int, float64) {
+	set := make(map[string]struct{}, len(a))
+	for _, e := range a {
+		set[e] = struct{}{}
+	}
+
+	var (
+		shared int
+		union  = len(set)
+		seen   = make(map[string]struct{}, len(b))
+	)
+	for _, e := range b {
+		if _, ok := seen[e]; ok {
+			continue
+		}
+		seen[e] = struct{}{}
+		if _, ok := set[e]; ok {
+			shared++
+		} else {
+			union++
+		}
+	}
+
+	if union == 0 {
+		return 0, 0
+	}
+	return shared, float64(shared) / float64(union)
+}
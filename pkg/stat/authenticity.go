@@ -0,0 +1,150 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"sort"
+	"time"
+
+	"github.com/anqiansong/github-compare/pkg/timex"
+)
+
+const (
+	// newAccountAge is the age under which an account is new when it stars.
+	newAccountAge = 2 * timeMonth
+	// lowSignalMin is the least number of the low signals of a low-signal
+	// account.
+	lowSignalMin = 4
+	// burstMinStars is the least number of the stars of a burst day.
+	burstMinStars = 10
+	// burstDeviations is how many median absolute deviations a burst day is
+	// above the median.
+	burstDeviations = 5
+)
+
+// StarAuthenticity estimates how many stars come from the accounts with
+// little activity, which is typical of the bought stars.
+type StarAuthenticity struct {
+	Accounts  int `json:"accounts"`
+	LowSignal int `json:"lowSignal"`
+}
+
+// NewStarAuthenticity counts the low-signal ones in accounts.
+func NewStarAuthenticity(accounts []StargazerAccount) StarAuthenticity {
+	ret := StarAuthenticity{Accounts: len(accounts)}
+	for _, e := range accounts {
+		if e.lowSignal() {
+			ret.LowSignal++
+		}
+	}
+	return ret
+}
+
+// LowSignalShare returns the share of the low-signal accounts.
+func (s StarAuthenticity) LowSignalShare() float64 {
+	if s.Accounts == 0 {
+		return 0
+	}
+	return float64(s.LowSignal) / float64(s.Accounts)
+}
+
+// lowSignal reports whether at least lowSignalMin of the signals are missing:
+// an account older than newAccountAge, followers, followings, public
+// repositories and a bio.
+func (a StargazerAccount) lowSignal() bool {
+	var n int
+	for _, missing := range []bool{
+		a.StarredAt.Sub(a.CreatedAt) < newAccountAge,
+		a.Followers == 0,
+		a.Following == 0,
+		a.PublicRepos == 0,
+		!a.HasBio,
+	} {
+		if missing {
+			n++
+		}
+	}
+	return n >= lowSignalMin
+}
+
+// bursts reports the days of chart whose stars are at least burstMinStars and
+// more than burstDeviations median absolute deviations above the median.
+func bursts(chart Chart) []bool {
+	if len(chart.Data) == 0 {
+		return nil
+	}
+
+	median, mad := burstBaseline(chart.Data)
+	ret := make([]bool, len(chart.Data))
+	for i, e := range chart.Data {
+		ret[i] = e >= burstMinStars && e > median+burstDeviations*mad
+	}
+	return ret
+}
+
+// burstBaseline returns the median and the median absolute deviation of data,
+// the deviation is at least 1.
+func burstBaseline(data []float64) (float64, float64) {
+	var (
+		median     = medianOf(data)
+		deviations = make([]float64, 0, len(data))
+	)
+	for _, e := range data {
+		if e > median {
+			deviations = append(deviations, e-median)
+		} else {
+			deviations = append(deviations, median-e)
+		}
+	}
+	mad := medianOf(deviations)
+	if mad < 1 {
+		mad = 1
+	}
+	return median, mad
+}
+
+func medianOf(list []float64) float64 {
+	sorted := append([]float64(nil), list...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// burstDays returns the dates of the highlighted days of a daily chart which
+// ends today.
+func burstDays(highlights []bool) []string {
+	var (
+		list  []string
+		today = timex.Truncate(time.Now())
+	)
+	for i, e := range highlights {
+		if e {
+			day := today.AddDate(0, 0, i-len(highlights)+1)
+			list = append(list, day.Format("01-02"))
+		}
+	}
+	return list
+}
@@ -0,0 +1,84 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewStarAuthenticity(t *testing.T) {
+	now := time.Now()
+	accounts := []StargazerAccount{
+		{StarredAt: now, CreatedAt: now.Add(-timeDay)},
+		{StarredAt: now, CreatedAt: now.Add(-timeYear), Followers: 0, PublicRepos: 0},
+		{StarredAt: now, CreatedAt: now.Add(-timeDay), Followers: 3, Following: 1},
+		{StarredAt: now, CreatedAt: now.Add(-timeYear), Followers: 10, Following: 5,
+			PublicRepos: 20, HasBio: true},
+	}
+
+	s := NewStarAuthenticity(accounts)
+	if s.Accounts != 4 || s.LowSignal != 2 || s.LowSignalShare() != 0.5 {
+		t.Fatalf("unexpected authenticity: %+v", s)
+	}
+}
+
+func TestBursts(t *testing.T) {
+	chart := Chart{Data: []float64{3, 2, 4, 3, 60, 2, 3, 9, 5}}
+	highlights := bursts(chart)
+	for i, e := range highlights {
+		if e != (i == 4) {
+			t.Fatalf("unexpected bursts: %v", highlights)
+		}
+	}
+
+	if days := burstDays(highlights); len(days) != 1 ||
+		days[0] != time.Now().Truncate(timeDay).AddDate(0, 0, -4).Format("01-02") {
+		t.Fatalf("unexpected burst days: %v", days)
+	}
+	if bursts(Chart{}) != nil {
+		t.Fatal("expected no bursts of an empty chart")
+	}
+}
+
+func TestUnknownStargazers(t *testing.T) {
+	var edges StargazerEdges
+	if edges.times() != nil || edges.accounts() != nil {
+		t.Fatal("expected unknown stargazers")
+	}
+	if d := NewData(Result{}, Windows{}); d.LatestMonthStarCount != "N/A" ||
+		d.LowSignalStars != "N/A" {
+		t.Fatalf("unexpected data: %s, %s", d.LatestMonthStarCount, d.LowSignalStars)
+	}
+
+	r := Result{
+		Stargazers:          []time.Time{time.Now()},
+		StargazerAccounts:   []StargazerAccount{{StarredAt: time.Now()}},
+		StargazersTruncated: true,
+	}
+	d := NewData(r, Windows{})
+	if d.LatestMonthStarCount != "1+" || !strings.HasSuffix(d.LowSignalStars, "(latest 1 stars)") {
+		t.Fatalf("truncation not shown: %s, %s", d.LatestMonthStarCount, d.LowSignalStars)
+	}
+}
@@ -0,0 +1,111 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	// repositoryQueryCost estimates the nodes requested by Repository: 100
+	// topics, 10 languages, 1 release, 1 watcher, the 4 counts of issues and
+	// pull requests, the 6 single objects(latest release, license, primary
+	// language, code of conduct, contributing guidelines and CODEOWNERS) and
+	// at most 10 each of the funding links, issue templates and pull request
+	// templates.
+	repositoryQueryCost = 100 + 10 + 1 + 1 + 4 + 6 + 3*10
+	// maxBatchQueryCost limits the nodes requested by a single query.
+	maxBatchQueryCost = 5000
+)
+
+var repositoryType = reflect.TypeOf(Repository{})
+
+// Repositories fetches the repositories by aliased queries, each query covers
+// as many repositories as the cost limit allows. Repositories which can not be
+// resolved are absent from the result.
+func Repositories(ctx context.Context, client *githubv4.Client,
+	repos ...string) map[string]Repository {
+	var (
+		ret       = make(map[string]Repository, len(repos))
+		chunkSize = maxBatchQueryCost / repositoryQueryCost
+	)
+
+	for start := 0; start < len(repos); start += chunkSize {
+		end := start + chunkSize
+		if end > len(repos) {
+			end = len(repos)
+		}
+
+		chunk := repos[start:end]
+		query := reflect.New(batchQueryType(len(chunk)))
+		arg := repositoryVariables()
+		for i, r := range chunk {
+			owner, name := splitRepo(r)
+			arg[fmt.Sprintf("owner%d", i)] = githubv4.String(owner)
+			arg[fmt.Sprintf("name%d", i)] = githubv4.String(name)
+		}
+
+		for _, r := range chunk {
+			reportPage(ctx, r, StageMetadata, 1)
+		}
+
+		// the errors of unresolvable repositories come along with the data of
+		// the others, so the data is used anyway.
+		err := client.Query(ctx, query.Interface(), arg)
+		for i, r := range chunk {
+			repo := query.Elem().Field(i).Interface().(Repository)
+			if len(repo.NameWithOwner) == 0 {
+				if err == nil {
+					err = errors.New("repository not found")
+				}
+				reportDone(ctx, r, StageMetadata, err)
+				continue
+			}
+			ret[r] = repo
+			reportDone(ctx, r, StageMetadata, nil)
+		}
+	}
+
+	return ret
+}
+
+// batchQueryType builds a query type with n aliased repository fields, e.g.
+//
+//	r0: repository(owner: $owner0, name: $name0) { ... }
+func batchQueryType(n int) reflect.Type {
+	fields := make([]reflect.StructField, n)
+	for i := range fields {
+		fields[i] = reflect.StructField{
+			Name: fmt.Sprintf("R%d", i),
+			Type: repositoryType,
+			Tag: reflect.StructTag(fmt.Sprintf(`graphql:"r%d: repository(owner: $owner%d, name: $name%d)"`,
+				i, i, i)),
+		}
+	}
+	return reflect.StructOf(fields)
+}
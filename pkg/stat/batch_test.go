@@ -0,0 +1,103 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+)
+
+func TestRepositories(t *testing.T) {
+	var requests int
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+			return
+		}
+
+		var fields []string
+		for i := 0; ; i++ {
+			if !strings.Contains(body.Query, fmt.Sprintf("r%d: repository(owner: $owner%d,", i, i)) {
+				break
+			}
+			name := body.Variables[fmt.Sprintf("name%d", i)]
+			if name == "missing" {
+				fields = append(fields, fmt.Sprintf(`"r%d":null`, i))
+				continue
+			}
+			fields = append(fields, fmt.Sprintf(`"r%d":{"nameWithOwner":"%s/%s","stargazerCount":%d,"openIssues":{"totalCount":3}}`,
+				i, body.Variables[fmt.Sprintf("owner%d", i)], name, i))
+		}
+		fmt.Fprintf(w, `{"data":{%s},"errors":[{"message":"Could not resolve to a Repository"}]}`,
+			strings.Join(fields, ","))
+	}))
+	defer svr.Close()
+
+	var repos []string
+	for i := 0; i < 60; i++ {
+		repos = append(repos, fmt.Sprintf("owner/repo%d", i))
+	}
+	repos = append(repos, "owner/missing")
+
+	var (
+		started int
+		failed  []string
+	)
+	ctx := WithProgress(context.Background(), func(e Event) {
+		switch {
+		case !e.Done:
+			started++
+		case e.Err != nil:
+			failed = append(failed, e.Repo)
+		}
+	})
+
+	client := githubv4.NewEnterpriseClient(svr.URL, svr.Client())
+	ret := Repositories(ctx, client, repos...)
+	if requests != 2 {
+		t.Fatalf("expected 2 requests, got %d", requests)
+	}
+	if len(ret) != 60 {
+		t.Fatalf("expected 60 repositories, got %d", len(ret))
+	}
+	if r := ret["owner/repo50"]; r.NameWithOwner != "owner/repo50" || r.OpenIssues.TotalCount != 3 ||
+		r.StargazerCount != 50-maxBatchQueryCost/repositoryQueryCost {
+		t.Fatalf("unexpected repository: %+v", r)
+	}
+	if started != 61 || len(failed) != 1 || failed[0] != "owner/missing" {
+		t.Fatalf("unexpected progress: %d started, %v failed", started, failed)
+	}
+}
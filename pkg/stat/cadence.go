@@ -0,0 +1,139 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// semverPattern matches the version in a tag like v1.2.3, release-1.2 or
+// pkg@1.2.3-rc.1.
+var semverPattern = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?(-[0-9A-Za-z.-]+)?`)
+
+// ReleaseStats summarizes the releases, the intervals are measured between
+// the stable releases.
+type ReleaseStats struct {
+	Count       int `json:"count"`
+	Stable      int `json:"stable"`
+	Prereleases int `json:"prereleases"`
+	// Major, Minor and Patch count the stable releases by the semver part
+	// which is bumped, e.g. v2.0.0, v1.3.0 and v1.3.4.
+	Major          int           `json:"major"`
+	Minor          int           `json:"minor"`
+	Patch          int           `json:"patch"`
+	MedianInterval time.Duration `json:"medianInterval"`
+	// RecentInterval and EarlierInterval are the median intervals of the
+	// newer and the older half of the intervals.
+	RecentInterval  time.Duration `json:"recentInterval"`
+	EarlierInterval time.Duration `json:"earlierInterval"`
+	LatestStableAt  time.Time     `json:"latestStableAt"`
+	Downloads       int           `json:"downloads"`
+}
+
+// NewReleaseStats summarizes releases.
+func NewReleaseStats(releases []PublishedRelease) ReleaseStats {
+	var (
+		ret    = ReleaseStats{Count: len(releases)}
+		stable []time.Time
+	)
+
+	for _, e := range releases {
+		ret.Downloads += e.Downloads
+		match := semverPattern.FindStringSubmatch(e.Tag)
+		if e.Prerelease || len(match) > 0 && len(match[4]) > 0 {
+			ret.Prereleases++
+			continue
+		}
+
+		ret.Stable++
+		stable = append(stable, e.PublishedAt)
+		if e.PublishedAt.After(ret.LatestStableAt) {
+			ret.LatestStableAt = e.PublishedAt
+		}
+		if len(match) > 0 {
+			switch {
+			case isZero(match[2]) && isZero(match[3]) && !isZero(match[1]):
+				ret.Major++
+			case isZero(match[3]):
+				ret.Minor++
+			default:
+				ret.Patch++
+			}
+		}
+	}
+
+	sort.Slice(stable, func(i, j int) bool {
+		return stable[i].After(stable[j])
+	})
+	var intervals []time.Duration
+	for i := 1; i < len(stable); i++ {
+		intervals = append(intervals, stable[i-1].Sub(stable[i]))
+	}
+
+	ret.MedianInterval = percentile(intervals, 50)
+	if half := len(intervals) / 2; half > 0 {
+		ret.RecentInterval = percentile(intervals[:half], 50)
+		ret.EarlierInterval = percentile(intervals[half:], 50)
+	}
+	return ret
+}
+
+// IntervalTrend returns the ratio of the recent interval to the earlier one,
+// less than 1 means releasing faster, 0 means unknown.
+func (r ReleaseStats) IntervalTrend() float64 {
+	if r.EarlierInterval == 0 {
+		return 0
+	}
+	return float64(r.RecentInterval) / float64(r.EarlierInterval)
+}
+
+// releaseTimeline counts the releases per month of the latest year.
+func releaseTimeline(releases []PublishedRelease) Chart {
+	var (
+		now    = time.Now()
+		start  = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -11, 0)
+		labels = make([]string, 12)
+		data   = make([]float64, 12)
+	)
+	for i := range labels {
+		labels[i] = start.AddDate(0, i, 0).Format("01")
+	}
+	for _, e := range releases {
+		t := e.PublishedAt.In(now.Location())
+		i := (t.Year()-start.Year())*12 + int(t.Month()) - int(start.Month())
+		if i >= 0 && i < len(data) {
+			data[i]++
+		}
+	}
+
+	return Chart{Data: data, Labels: labels}
+}
+
+// isZero reports whether a numeric part of a version is 0 or absent.
+func isZero(s string) bool {
+	n, _ := strconv.Atoi(s)
+	return n == 0
+}
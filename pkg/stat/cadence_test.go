@@ -0,0 +1,75 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewReleaseStats(t *testing.T) {
+	var (
+		now  = time.Now()
+		days = func(n int) time.Time { return now.Add(-time.Duration(n) * timeDay) }
+	)
+	releases := []PublishedRelease{
+		{Tag: "v2.0.0", PublishedAt: days(1), Downloads: 10},
+		{Tag: "v2.0.0-rc.1", PublishedAt: days(3)},
+		{Tag: "v1.3.1", PublishedAt: days(5), Downloads: 5},
+		{Tag: "v1.3.0", PublishedAt: days(10)},
+		{Tag: "nightly", Prerelease: true, PublishedAt: days(12)},
+		{Tag: "v1.2.0", PublishedAt: days(30)},
+		{Tag: "v1.1.0", PublishedAt: days(60)},
+	}
+
+	r := NewReleaseStats(releases)
+	if r.Count != 7 || r.Stable != 5 || r.Prereleases != 2 || r.Downloads != 15 {
+		t.Fatalf("unexpected counts: %+v", r)
+	}
+	if r.Major != 1 || r.Minor != 3 || r.Patch != 1 {
+		t.Fatalf("unexpected cadence: %+v", r)
+	}
+	// the intervals are 4, 5, 20 and 30 days from the newest
+	if r.MedianInterval != 5*timeDay || r.RecentInterval != 4*timeDay ||
+		r.EarlierInterval != 20*timeDay {
+		t.Fatalf("unexpected intervals: %+v", r)
+	}
+	if r.IntervalTrend() != 0.2 || !r.LatestStableAt.Equal(days(1)) {
+		t.Fatalf("unexpected trend: %v, %v", r.IntervalTrend(), r.LatestStableAt)
+	}
+}
+
+func TestTruncatedReleases(t *testing.T) {
+	list := []PublishedRelease{{Tag: "v1.0.0", PublishedAt: time.Now(), Downloads: 10}}
+
+	if d := NewData(Result{Releases: list}, Windows{}); d.ReleaseDownloads != "10" {
+		t.Fatalf("unexpected downloads: %s", d.ReleaseDownloads)
+	}
+	d := NewData(Result{Releases: list, ReleasesTruncated: true}, Windows{})
+	if d.ReleaseDownloads != "10+ (latest 500 releases, 50 assets each)" {
+		t.Fatalf("truncation not shown: %s", d.ReleaseDownloads)
+	}
+	if d := NewData(Result{}, Windows{}); d.ReleaseDownloads != "N/A" {
+		t.Fatalf("unexpected unknown downloads: %s", d.ReleaseDownloads)
+	}
+}
@@ -25,63 +25,29 @@ package stat
 import (
 	"time"
 
-	"github.com/anqiansong/github-compare/pkg/timex"
 	"github.com/google/go-github/v44/github"
 )
 
 type CommitList []*github.RepositoryCommit
 
-func (c CommitList) Chart() Chart {
-	var (
-		labels  []string
-		data    []float64
-		now     = time.Now()
-		dayTime = timex.AllDays(now.Add(-weekDur), now)
-	)
-
-	for _, t := range dayTime {
-		label := t.Format(labelLayout)
-		labels = append(labels, label)
-		data = append(data, float64(c.getSpecifiedDate(t)))
-	}
-
-	return Chart{Data: data, Labels: labels}
-}
-
-func (c CommitList) getSpecifiedDate(date time.Time) int {
-	var (
-		count int
-		zero  = timex.Truncate(date)
-	)
-
+func (c CommitList) times() timeList {
+	list := make(timeList, 0, len(c))
 	for _, e := range c {
-		commit := e.Commit
-		if commit == nil {
-			continue
-		}
-
-		committer := commit.Author
-		if committer == nil {
-			continue
-		}
-
-		if timex.Truncate(committer.GetDate()).Equal(zero) {
-			count += 1
-		}
+		list = append(list, e.GetCommit().GetAuthor().GetDate())
 	}
-
-	return count
+	return list
 }
 
-func (s Stat) latestWeekCommits() CommitList {
+func (s Stat) commits() CommitList {
 	var (
 		page  = 1
 		list  CommitList
 		until = time.Now()
-		since = time.Now().Add(-timeWeek)
+		since = time.Now().Add(-s.windows.Activity)
 	)
 
 	for {
+		s.reportPage(StageCommits, page)
 		ret, resp, err := s.restClient.Repositories.ListCommits(s.ctx, s.owner, s.repo,
 			&github.CommitsListOptions{
 				Since: since,
@@ -92,11 +58,13 @@ func (s Stat) latestWeekCommits() CommitList {
 				},
 			})
 		if err != nil {
+			s.reportDone(StageCommits, err)
 			return list
 		}
 
 		list = append(list, ret...)
 		if page >= resp.LastPage {
+			s.reportDone(StageCommits, nil)
 			return list
 		}
 
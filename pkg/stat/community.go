@@ -0,0 +1,141 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"net/http"
+
+	"github.com/shurcooL/githubv4"
+)
+
+type (
+	FundingLink struct {
+		Platform githubv4.String
+		URL      githubv4.String
+	}
+
+	CodeOfConduct struct {
+		Name githubv4.String
+	}
+
+	ContributingGuidelines struct {
+		Title githubv4.String
+	}
+
+	IssueTemplate struct {
+		Name githubv4.String
+	}
+
+	PullRequestTemplate struct {
+		Filename githubv4.String
+	}
+
+	CodeownersError struct {
+		Line githubv4.Int
+	}
+
+	Codeowners struct {
+		Errors []CodeownersError
+	}
+
+	// Community is the community health files and the governance flags of a
+	// repository.
+	Community struct {
+		Readme              bool     `json:"readme"`
+		Contributing        bool     `json:"contributing"`
+		CodeOfConduct       bool     `json:"codeOfConduct"`
+		SecurityPolicy      bool     `json:"securityPolicy"`
+		IssueTemplates      bool     `json:"issueTemplates"`
+		PullRequestTemplate bool     `json:"pullRequestTemplate"`
+		Funding             bool     `json:"funding"`
+		Codeowners          bool     `json:"codeowners"`
+		Discussions         bool     `json:"discussions"`
+		Archived            bool     `json:"archived"`
+		Fork                bool     `json:"fork"`
+		Template            bool     `json:"template"`
+		FundingLinks        []string `json:"fundingLinks,omitempty"`
+	}
+
+	// CommunityItem is an item of the community checklist.
+	CommunityItem struct {
+		Name    string `json:"name"`
+		Present bool   `json:"present"`
+	}
+)
+
+func (r Repository) community() *Community {
+	c := &Community{
+		Contributing:        r.ContributingGuidelines != nil,
+		CodeOfConduct:       r.CodeOfConduct != nil,
+		SecurityPolicy:      bool(r.IsSecurityPolicyEnabled),
+		IssueTemplates:      len(r.IssueTemplates) > 0,
+		PullRequestTemplate: len(r.PullRequestTemplates) > 0,
+		Funding:             len(r.FundingLinks) > 0,
+		Codeowners:          r.Codeowners != nil,
+		Discussions:         bool(r.HasDiscussionsEnabled),
+		Archived:            bool(r.IsArchived),
+		Fork:                bool(r.IsFork),
+		Template:            bool(r.IsTemplate),
+	}
+	for _, e := range r.FundingLinks {
+		c.FundingLinks = append(c.FundingLinks, string(e.URL))
+	}
+	return c
+}
+
+// Checklist returns the community health items in display order.
+func (c Community) Checklist() []CommunityItem {
+	return []CommunityItem{
+		{Name: "README", Present: c.Readme},
+		{Name: "CONTRIBUTING", Present: c.Contributing},
+		{Name: "CODE_OF_CONDUCT", Present: c.CodeOfConduct},
+		{Name: "SECURITY", Present: c.SecurityPolicy},
+		{Name: "issue templates", Present: c.IssueTemplates},
+		{Name: "PR template", Present: c.PullRequestTemplate},
+		{Name: "FUNDING", Present: c.Funding},
+		{Name: "CODEOWNERS", Present: c.Codeowners},
+		{Name: "discussions", Present: c.Discussions},
+	}
+}
+
+// Health returns the number of the present items of Checklist.
+func (c Community) Health() int {
+	var n int
+	for _, e := range c.Checklist() {
+		if e.Present {
+			n++
+		}
+	}
+	return n
+}
+
+// hasReadme reports whether the repository has a README which GitHub renders.
+func (s Stat) hasReadme() bool {
+	s.reportPage(StageCommunity, 1)
+	_, resp, err := s.restClient.Repositories.GetReadme(s.ctx, s.owner, s.repo, nil)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		err = nil
+	}
+	s.reportDone(StageCommunity, err)
+	return err == nil && resp != nil && resp.StatusCode == http.StatusOK
+}
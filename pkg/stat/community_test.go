@@ -0,0 +1,51 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import "testing"
+
+func TestRepositoryCommunity(t *testing.T) {
+	repo := Repository{
+		IsArchived:             true,
+		IsFork:                 true,
+		CodeOfConduct:          &CodeOfConduct{Name: "Contributor Covenant"},
+		ContributingGuidelines: &ContributingGuidelines{},
+		FundingLinks:           []FundingLink{{Platform: "GITHUB", URL: "https://github.com/sponsors/a"}},
+		IssueTemplates:         []IssueTemplate{{Name: "Bug report"}},
+	}
+
+	c := repo.community()
+	c.Readme = true
+	if !c.Archived || !c.Fork || c.Template || !c.Funding || c.SecurityPolicy {
+		t.Fatalf("unexpected community: %+v", c)
+	}
+	if c.Health() != 5 || len(c.Checklist()) != 9 {
+		t.Fatalf("unexpected health: %d of %d", c.Health(), len(c.Checklist()))
+	}
+
+	d := NewData(Result{Community: c}, Windows{})
+	if d.Archived != "⚠ ARCHIVED" || d.RepositoryKind != "fork" || d.CommunityHealth != "5/9" ||
+		d.Funding != "https://github.com/sponsors/a" {
+		t.Fatalf("unexpected data: %+v", d)
+	}
+}
@@ -0,0 +1,108 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"sort"
+	"time"
+)
+
+// activeWindow is the period in which a committer is considered active.
+const activeWindow = timeQuarter
+
+// Concentration summarizes how the commits are distributed among the authors
+// and their organizations.
+type Concentration struct {
+	Commits int `json:"commits"`
+	Authors int `json:"authors"`
+	// TopShares are the shares of the commits by the top 1, 3 and 5 authors.
+	TopShares [3]float64 `json:"topShares"`
+	// BusFactor is the least number of authors who made half of the commits.
+	BusFactor int `json:"busFactor"`
+	// ActiveMaintainers is the number of the authors who committed within the
+	// latest 90 days.
+	ActiveMaintainers int `json:"activeMaintainers"`
+	// Organizations is the number of the known organizations of the authors.
+	Organizations        int     `json:"organizations"`
+	TopOrganization      string  `json:"topOrganization"`
+	TopOrganizationShare float64 `json:"topOrganizationShare"`
+}
+
+// NewConcentration analyses the commits in history.
+func NewConcentration(history []AuthoredCommit) Concentration {
+	var (
+		ret      = Concentration{Commits: len(history)}
+		authors  = make(map[string]int)
+		orgs     = make(map[string]int)
+		active   = make(map[string]struct{})
+		deadline = time.Now().Add(-activeWindow)
+	)
+	if len(history) == 0 {
+		return ret
+	}
+
+	for _, e := range history {
+		authors[e.Author]++
+		if len(e.Organization) > 0 {
+			orgs[e.Organization]++
+		}
+		if e.AuthoredAt.After(deadline) {
+			active[e.Author] = struct{}{}
+		}
+	}
+
+	counts := make([]int, 0, len(authors))
+	for _, c := range authors {
+		counts = append(counts, c)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(counts)))
+
+	var sum int
+	for i, c := range counts {
+		sum += c
+		if ret.BusFactor == 0 && sum*2 >= len(history) {
+			ret.BusFactor = i + 1
+		}
+		for j, n := range []int{1, 3, 5} {
+			if i+1 == n || i+1 == len(counts) && i+1 < n {
+				ret.TopShares[j] = float64(sum) / float64(len(history))
+			}
+		}
+	}
+
+	var orgCommits, topCommits int
+	for org, c := range orgs {
+		orgCommits += c
+		if c > topCommits || c == topCommits && org < ret.TopOrganization {
+			ret.TopOrganization, topCommits = org, c
+		}
+	}
+	if orgCommits > 0 {
+		ret.TopOrganizationShare = float64(topCommits) / float64(orgCommits)
+	}
+
+	ret.Authors = len(authors)
+	ret.ActiveMaintainers = len(active)
+	ret.Organizations = len(orgs)
+	return ret
+}
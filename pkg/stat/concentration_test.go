@@ -0,0 +1,91 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewConcentration(t *testing.T) {
+	var (
+		now    = time.Now()
+		recent = now.Add(-timeDay)
+		old    = now.Add(-200 * timeDay)
+		list   []AuthoredCommit
+	)
+	add := func(author, org string, at time.Time, n int) {
+		for i := 0; i < n; i++ {
+			list = append(list, AuthoredCommit{Author: author, Organization: org, AuthoredAt: at})
+		}
+	}
+	add("alice", "acme", recent, 6)
+	add("bob", "acme", old, 2)
+	add("carol", "initech", recent, 1)
+	add("dave", "", old, 1)
+
+	c := NewConcentration(list)
+	if c.Commits != 10 || c.Authors != 4 || c.BusFactor != 1 || c.ActiveMaintainers != 2 {
+		t.Fatalf("unexpected concentration: %+v", c)
+	}
+	if c.TopShares[0] != 0.6 || c.TopShares[1] != 0.9 || c.TopShares[2] != 1 {
+		t.Fatalf("unexpected top shares: %v", c.TopShares)
+	}
+	if c.Organizations != 2 || c.TopOrganization != "acme" || c.TopOrganizationShare != 8.0/9 {
+		t.Fatalf("unexpected organizations: %+v", c)
+	}
+}
+
+func TestTruncatedHistory(t *testing.T) {
+	list := []AuthoredCommit{{Author: "alice", AuthoredAt: time.Now().Add(-3 * timeDay)}}
+
+	d := NewData(Result{History: list}, Windows{})
+	if strings.Contains(d.BusFactor, "latest") || d.HistoryWindow != "last 365 day(s)" {
+		t.Fatalf("unexpected history: %s, %s", d.BusFactor, d.HistoryWindow)
+	}
+
+	d = NewData(Result{History: list, HistoryTruncated: true}, Windows{})
+	if !strings.HasSuffix(d.TopContributors, "(latest 1 commits)") ||
+		!strings.HasSuffix(d.BusFactor, "(latest 1 commits)") {
+		t.Fatalf("truncation not shown: %s, %s", d.TopContributors, d.BusFactor)
+	}
+	if d.HistoryWindow != "last 3 day(s), truncated at 1 commits" {
+		t.Fatalf("unexpected history window: %s", d.HistoryWindow)
+	}
+}
+
+func TestOrganization(t *testing.T) {
+	cases := map[[2]string]string{
+		{"@Acme ", "a@b.com"}:                      "acme",
+		{"", "dev@redhat.com"}:                     "redhat.com",
+		{"", "someone@gmail.com"}:                  "",
+		{"", "1+someone@users.noreply.github.com"}: "",
+		{"", "invalid"}:                            "",
+	}
+	for in, want := range cases {
+		if got := organization(in[0], in[1]); got != want {
+			t.Errorf("organization(%q, %q) = %q, want %q", in[0], in[1], got, want)
+		}
+	}
+}
@@ -30,6 +30,15 @@ func (s Stat) ContributorCount() int {
 		ListOptions: github.ListOptions{Page: 1, PerPage: 1},
 	}
 
-	_, resp, _ := s.restClient.Repositories.ListContributors(s.ctx, s.owner, s.repo, listOpt)
-	return s.GetTotal(resp)
+	s.reportPage(StageContributors, 1)
+	list, resp, err := s.restClient.Repositories.ListContributors(s.ctx, s.owner, s.repo, listOpt)
+	s.reportDone(StageContributors, err)
+	if err != nil {
+		return Unknown
+	}
+	if total := s.GetTotal(resp); total > 0 {
+		return total
+	}
+	// there is no last page if all contributors fit in the first one
+	return len(list)
 }
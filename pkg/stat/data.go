@@ -0,0 +1,636 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	Data struct {
+		Age                  string `json:"age"`
+		AvgReleasePeriod     string `json:"avgReleasePeriod,omitempty"`
+		ContributorCount     string `json:"contributorCount,omitempty"`
+		ForkCount            string `json:"forkCount,omitempty"`
+		FullName             string `json:"fullName,omitempty"`
+		Homepage             string `json:"homepage,omitempty"`
+		Issue                string `json:"issue"`
+		Language             string `json:"language,omitempty"`
+		LanguageBreakdown    string `json:"languageBreakdown"`
+		LastPushedAt         string `json:"lastPushedAt"`
+		LatestReleaseAt      string `json:"latestReleaseAt"`
+		LastUpdatedAt        string `json:"lastUpdatedAt"`
+		LatestDayStarCount   string `json:"latestDayStarCount"`
+		LatestMonthStarCount string `json:"latestMonthStarCount"`
+		LatestWeekStarCount  string `json:"latestWeekStarCount"`
+		License              string `json:"license,omitempty"`
+		Pull                 string `json:"pull"`
+		ReleaseCount         string `json:"releaseCount,omitempty"`
+		StarCount            string `json:"starCount,omitempty"`
+		WatcherCount         string `json:"watcherCount,omitempty"`
+		Rank                 int    `json:"rank,omitempty"`
+
+		PullMergeRatio     string `json:"pullMergeRatio"`
+		PullMergeTime      string `json:"pullMergeTime"`
+		PullFirstResponse  string `json:"pullFirstResponse"`
+		FirstTimePullShare string `json:"firstTimePullShare"`
+		OpenPullAge        string `json:"openPullAge"`
+		IssueFirstResponse string `json:"issueFirstResponse"`
+		IssueCloseTime     string `json:"issueCloseTime"`
+		StaleIssueRatio    string `json:"staleIssueRatio"`
+		IssueBacklog       string `json:"issueBacklog"`
+		TopContributors    string `json:"topContributors"`
+		BusFactor          string `json:"busFactor"`
+		ActiveMaintainers  string `json:"activeMaintainers"`
+		Organizations      string `json:"organizations"`
+		WeekendCommits     string `json:"weekendCommits"`
+		OfficeHourCommits  string `json:"officeHourCommits"`
+		CommitTimeZones    string `json:"commitTimeZones"`
+		HistoryWindow      string `json:"historyWindow"`
+		ReleaseInterval    string `json:"releaseInterval"`
+		ReleaseTrend       string `json:"releaseTrend"`
+		ReleaseCadence     string `json:"releaseCadence"`
+		LastStableRelease  string `json:"lastStableRelease"`
+		ReleaseDownloads   string `json:"releaseDownloads"`
+		LowSignalStars     string `json:"lowSignalStars"`
+		StarBursts         string `json:"starBursts"`
+		Archived           string `json:"archived"`
+		RepositoryKind     string `json:"repositoryKind"`
+		Funding            string `json:"funding"`
+		CommunityHealth    string `json:"communityHealth"`
+		StarProjection     string `json:"starProjection"`
+		NotableEvents      string `json:"notableEvents"`
+		ForkProjection     string `json:"forkProjection"`
+
+		Description           string          `json:"description,omitempty"`
+		Tags                  []string        `json:"tags,omitempty"`
+		Languages             []LanguageShare `json:"languages,omitempty"`
+		LatestMonthStargazers Chart           `json:"latestMonthStargazers"`
+
+		LatestWeekForks   Chart `json:"latestWeekForks"`
+		LatestWeekCommits Chart `json:"latestWeekCommits"`
+		LatestWeekPulls   Chart `json:"latestWeekPulls"`
+		LatestWeekIssues  Chart `json:"latestWeekIssues"`
+
+		PunchCard       *PunchCard `json:"punchCard,omitempty"`
+		ReleaseTimeline Chart      `json:"releaseTimeline"`
+		Community       *Community `json:"community,omitempty"`
+
+		StarGrowth   *Growth      `json:"starGrowth,omitempty"`
+		StarForecast []Projection `json:"starForecast,omitempty"`
+		ForkGrowth   *Growth      `json:"forkGrowth,omitempty"`
+		ForkForecast []Projection `json:"forkForecast,omitempty"`
+
+		Anomalies []Anomaly `json:"anomalies,omitempty"`
+
+		Metrics Metrics `json:"metrics"`
+		Score   *Score  `json:"score,omitempty"`
+	}
+
+	Chart struct {
+		Data   []float64 `json:"data"`
+		Labels []string  `json:"labels"`
+		// Highlights marks the anomalous values of Data.
+		Highlights []bool `json:"highlights,omitempty"`
+		// Trends marks the spikes and the drops of Data against the rolling
+		// baseline, the spikes of stars follow Highlights, see TrendSpike and
+		// TrendDrop.
+		Trends []int `json:"trends,omitempty"`
+	}
+)
+
+// NewData formats r for presentation, windows must be the ones which r is
+// fetched with.
+func NewData(r Result, windows Windows) Data {
+	windows = windows.OrDefault()
+	var (
+		ageDuration          time.Duration
+		ageDays              int
+		avgStarCount         = r.StarCount
+		avgForkCount         = r.ForkCount
+		avgReleasePeriod     time.Duration
+		stargazers           = timeList(r.Stargazers)
+		dayStars, dayTrend   = StarTrend(r.Stargazers, timeDay)
+		weekStars, weekTrend = StarTrend(r.Stargazers, timeWeek)
+	)
+	if !r.CreatedAt.IsZero() {
+		ageDuration = time.Since(r.CreatedAt)
+		ageDays = int(ageDuration.Hours() / 24)
+	}
+	if r.ReleaseCount > 0 {
+		avgReleasePeriod = ageDuration / time.Duration(r.ReleaseCount)
+	}
+	if ageDays > 1 {
+		avgStarCount = r.StarCount / ageDays
+		avgForkCount = r.ForkCount / ageDays
+	}
+
+	d := Data{
+		FullName:             r.FullName,
+		StarCount:            formatTotal(r.StarCount, avgStarCount),
+		LatestDayStarCount:   formatValue(""),
+		LatestWeekStarCount:  formatValue(""),
+		LatestMonthStarCount: formatValue(""),
+		ForkCount:            formatTotal(r.ForkCount, avgForkCount),
+		WatcherCount:         formatCount(r.WatcherCount),
+		Language:             formatValue(r.Language),
+		LanguageBreakdown:    formatLanguages(r.Languages),
+		Issue:                formatRatio(r.OpenIssueCount, r.IssueCount),
+		Pull:                 formatRatio(r.OpenPullCount, r.PullCount),
+		License:              formatValue(r.License),
+		Age:                  formatPeriod(ageDuration),
+		LastPushedAt:         formatDuration(r.PushedAt),
+		LastUpdatedAt:        formatDuration(r.UpdatedAt),
+		LatestReleaseAt:      formatDuration(r.LatestReleaseAt),
+		ReleaseCount:         formatCount(r.ReleaseCount),
+		AvgReleasePeriod:     formatPeriod(avgReleasePeriod),
+		ContributorCount:     formatCount(r.ContributorCount),
+		Homepage:             r.Homepage,
+		Description:          formatValue(r.Description),
+		Tags:                 r.Topics,
+		Languages:            r.Languages,
+		LatestWeekForks:      timeList(r.Forks).chart(windows.Activity),
+		LatestWeekCommits:    timeList(r.Commits).chart(windows.Activity),
+		LatestWeekPulls:      timeList(r.Pulls).chart(windows.Activity),
+		LatestWeekIssues:     timeList(r.Issues).chart(windows.Activity),
+		PullMergeRatio:       formatValue(""),
+		PullMergeTime:        formatValue(""),
+		PullFirstResponse:    formatValue(""),
+		FirstTimePullShare:   formatValue(""),
+		OpenPullAge:          formatValue(""),
+		IssueFirstResponse:   formatValue(""),
+		IssueCloseTime:       formatValue(""),
+		StaleIssueRatio:      formatValue(""),
+		IssueBacklog:         formatValue(""),
+		TopContributors:      formatValue(""),
+		BusFactor:            formatValue(""),
+		ActiveMaintainers:    formatValue(""),
+		Organizations:        formatValue(""),
+		WeekendCommits:       formatValue(""),
+		OfficeHourCommits:    formatValue(""),
+		CommitTimeZones:      formatValue(""),
+		HistoryWindow:        "last " + formatSpan(windows.History),
+		ReleaseInterval:      formatValue(""),
+		ReleaseTrend:         formatValue(""),
+		ReleaseCadence:       formatValue(""),
+		LastStableRelease:    formatValue(""),
+		ReleaseDownloads:     formatValue(""),
+		LowSignalStars:       formatValue(""),
+		StarBursts:           formatValue(""),
+		Archived:             formatValue(""),
+		RepositoryKind:       formatValue(""),
+		Funding:              formatValue(""),
+		CommunityHealth:      formatValue(""),
+		StarProjection:       formatValue(""),
+		NotableEvents:        formatValue(""),
+		ForkProjection:       formatValue(""),
+
+		Metrics: Metrics{
+			StarCount:             r.StarCount,
+			ForkCount:             r.ForkCount,
+			WatcherCount:          r.WatcherCount,
+			OpenIssueCount:        r.OpenIssueCount,
+			IssueCount:            r.IssueCount,
+			OpenPullCount:         r.OpenPullCount,
+			PullCount:             r.PullCount,
+			ContributorCount:      r.ContributorCount,
+			ReleaseCount:          r.ReleaseCount,
+			LatestDayStarCount:    dayStars,
+			LatestWeekStarCount:   weekStars,
+			LatestMonthStarCount:  len(stargazers),
+			LatestWeekCommitCount: len(r.Commits),
+			LatestWeekPullCount:   len(r.Pulls),
+			AgeDays:               ageDays,
+			AvgReleasePeriodDays:  avgReleasePeriod.Hours() / 24,
+			HasLicense:            len(r.License) > 0,
+			CreatedAt:             r.CreatedAt,
+			PushedAt:              r.PushedAt,
+			UpdatedAt:             r.UpdatedAt,
+			LatestReleaseAt:       r.LatestReleaseAt,
+		},
+	}
+
+	if r.PullLifecycles != nil {
+		pulls := NewPullStats(r.PullLifecycles, r.OpenPulls)
+		d.PullMergeRatio = fmt.Sprintf("%s (%d/%d)", formatPercent(pulls.MergedRatio()),
+			pulls.Merged, pulls.Merged+pulls.ClosedUnmerged)
+		d.FirstTimePullShare = formatPercent(pulls.FirstTimeContributorShare())
+		if pulls.Merged > 0 {
+			d.PullMergeTime = fmt.Sprintf("%s (p90 %s)", formatSpan(pulls.MedianTimeToMerge),
+				formatSpan(pulls.P90TimeToMerge))
+		}
+		if pulls.Responded > 0 {
+			d.PullFirstResponse = formatSpan(pulls.MedianTimeToFirstResponse)
+		}
+		d.OpenPullAge = formatAgeBuckets(pulls.OpenAges)
+		if r.PullsTruncated {
+			note := fmt.Sprintf(" (latest %d pulls)", len(r.PullLifecycles))
+			d.PullMergeRatio += note
+			d.PullMergeTime += note
+		}
+
+		d.Metrics.MergedPullRatio = pulls.MergedRatio()
+		d.Metrics.MergedPullCount = pulls.Merged
+		d.Metrics.MedianPullMergeHours = pulls.MedianTimeToMerge.Hours()
+		d.Metrics.P90PullMergeHours = pulls.P90TimeToMerge.Hours()
+		d.Metrics.RespondedPullCount = pulls.Responded
+		d.Metrics.MedianPullResponseHours = pulls.MedianTimeToFirstResponse.Hours()
+		d.Metrics.FirstTimePullShare = pulls.FirstTimeContributorShare()
+	}
+
+	if r.IssueLifecycles != nil {
+		issues := NewIssueStats(r.IssueLifecycles)
+		if issues.Responded > 0 {
+			d.IssueFirstResponse = formatSpan(issues.MedianTimeToFirstResponse)
+		}
+		if issues.Closed > 0 {
+			d.IssueCloseTime = formatSpan(issues.MedianTimeToClose)
+		}
+		d.StaleIssueRatio = fmt.Sprintf("%s (%d/%d)", formatPercent(issues.StaleRatio()),
+			issues.Stale, issues.Closed)
+		if r.IssuesTruncated {
+			note := fmt.Sprintf(" (latest %d issues)", len(r.IssueLifecycles))
+			d.IssueCloseTime += note
+			d.StaleIssueRatio += note
+		}
+
+		d.Metrics.RespondedIssueCount = issues.Responded
+		d.Metrics.MedianIssueResponseHours = issues.MedianTimeToFirstResponse.Hours()
+		d.Metrics.ClosedIssueCount = issues.Closed
+		d.Metrics.MedianIssueCloseHours = issues.MedianTimeToClose.Hours()
+		d.Metrics.StaleIssueRatio = issues.StaleRatio()
+	}
+
+	if r.IssueLifecycles != nil && r.IssuesOpened != Unknown && r.IssuesClosed != Unknown {
+		growth := r.IssuesOpened - r.IssuesClosed
+		d.IssueBacklog = fmt.Sprintf("%+d (%d opened, %d closed)", growth,
+			r.IssuesOpened, r.IssuesClosed)
+		d.Metrics.IssueBacklogGrowth = growth
+	}
+
+	if r.History != nil {
+		c := NewConcentration(r.History)
+		d.TopContributors = fmt.Sprintf("top1 %s, top3 %s, top5 %s", formatPercent(c.TopShares[0]),
+			formatPercent(c.TopShares[1]), formatPercent(c.TopShares[2]))
+		d.BusFactor = fmt.Sprintf("%d of %d author(s)", c.BusFactor, c.Authors)
+		if r.HistoryTruncated {
+			note := fmt.Sprintf(" (latest %d commits)", c.Commits)
+			d.TopContributors += note
+			d.BusFactor += note
+			d.HistoryWindow = fmt.Sprintf("last %s, truncated at %d commits",
+				formatSpan(time.Since(oldestCommit(r.History))), c.Commits)
+		}
+		d.ActiveMaintainers = formatValue(c.ActiveMaintainers)
+		d.Organizations = formatValue(c.Organizations)
+		if c.Organizations > 0 {
+			d.Organizations = fmt.Sprintf("%d (%s %s)", c.Organizations, c.TopOrganization,
+				formatPercent(c.TopOrganizationShare))
+		}
+
+		d.Metrics.HistoryCommitCount = c.Commits
+		d.Metrics.TopContributorShare = c.TopShares[0]
+		d.Metrics.Top3ContributorShare = c.TopShares[1]
+		d.Metrics.Top5ContributorShare = c.TopShares[2]
+		d.Metrics.BusFactor = c.BusFactor
+		d.Metrics.ActiveMaintainerCount = c.ActiveMaintainers
+		d.Metrics.OrganizationCount = c.Organizations
+		d.Metrics.TopOrganizationShare = c.TopOrganizationShare
+
+		punchCard := NewPunchCard(r.History)
+		d.PunchCard = &punchCard
+		d.WeekendCommits = formatPercent(punchCard.WeekendShare())
+		d.OfficeHourCommits = formatPercent(punchCard.OfficeHourShare())
+		d.CommitTimeZones = formatTimeZones(punchCard)
+	}
+
+	if r.Releases != nil {
+		releases := NewReleaseStats(r.Releases)
+		if releases.MedianInterval > 0 {
+			d.ReleaseInterval = formatSpan(releases.MedianInterval)
+		}
+		d.ReleaseTrend = formatReleaseTrend(releases)
+		d.ReleaseCadence = fmt.Sprintf("%d major, %d minor, %d patch, %d pre", releases.Major,
+			releases.Minor, releases.Patch, releases.Prereleases)
+		d.LastStableRelease = formatDuration(releases.LatestStableAt)
+		d.ReleaseDownloads = formatValue(releases.Downloads)
+		if r.ReleasesTruncated {
+			d.ReleaseDownloads += fmt.Sprintf("+ (latest %d releases, %d assets each)",
+				maxReleasePages*100, maxReleaseAssets)
+		}
+		d.ReleaseTimeline = releaseTimeline(r.Releases)
+
+		d.Metrics.StableReleaseCount = releases.Stable
+		d.Metrics.MedianReleaseIntervalDays = releases.MedianInterval.Hours() / 24
+		d.Metrics.ReleaseIntervalTrend = releases.IntervalTrend()
+		d.Metrics.LatestStableReleaseAt = releases.LatestStableAt
+		d.Metrics.ReleaseDownloads = releases.Downloads
+	}
+
+	if c := r.Community; c != nil {
+		d.Community = c
+		d.Archived = "no"
+		if c.Archived {
+			d.Archived = "⚠ ARCHIVED"
+		}
+		d.RepositoryKind = formatRepositoryKind(*c)
+		if len(c.FundingLinks) > 0 {
+			d.Funding = strings.Join(c.FundingLinks, "\n")
+		}
+		d.CommunityHealth = fmt.Sprintf("%d/%d", c.Health(), len(c.Checklist()))
+		d.Metrics.Archived = c.Archived
+		d.Metrics.CommunityHealth = c.Health()
+	}
+
+	if r.Stargazers != nil {
+		d.LatestDayStarCount = FormatStarTrend(dayStars, dayTrend)
+		d.LatestWeekStarCount = FormatStarTrend(weekStars, weekTrend)
+		d.LatestMonthStarCount = formatValue(len(stargazers))
+		if r.StargazersTruncated {
+			d.LatestMonthStarCount += "+"
+		}
+		d.LatestMonthStargazers = stargazers.chart(windows.Stars)
+		d.LatestMonthStargazers.Highlights = bursts(d.LatestMonthStargazers)
+
+		days := burstDays(d.LatestMonthStargazers.Highlights)
+		d.StarBursts = "none"
+		if len(days) > 0 {
+			d.StarBursts = strings.Join(days, ", ")
+		}
+		d.Metrics.StarBurstDays = len(days)
+	}
+
+	d.Anomalies = notableEvents(&d, r.Releases)
+	if r.Stargazers != nil || r.Forks != nil || r.Commits != nil || r.Issues != nil {
+		d.NotableEvents = "none"
+	}
+	if len(d.Anomalies) > 0 {
+		d.NotableEvents = formatAnomalies(d.Anomalies)
+	}
+	d.Metrics.AnomalyCount = len(d.Anomalies)
+
+	if r.StarCount >= 0 {
+		growth := NewGrowth(r.StarCount, completeDays(d.LatestMonthStargazers), ageDays)
+		d.StarGrowth = &growth
+		d.StarForecast = growth.Projections()
+		d.StarProjection = formatProjections(d.StarForecast)
+		d.Metrics.ProjectedStarCount = d.StarForecast[len(d.StarForecast)-1].Value
+	}
+
+	if r.ForkCount >= 0 {
+		forks := timeList(r.Forks).chart(windows.Activity)
+		growth := NewGrowth(r.ForkCount, completeDays(forks), ageDays)
+		d.ForkGrowth = &growth
+		d.ForkForecast = growth.Projections()
+		d.ForkProjection = formatProjections(d.ForkForecast)
+		d.Metrics.ProjectedForkCount = d.ForkForecast[len(d.ForkForecast)-1].Value
+	}
+
+	if r.StargazerAccounts != nil {
+		stars := NewStarAuthenticity(r.StargazerAccounts)
+		d.LowSignalStars = fmt.Sprintf("%s (%d/%d)", formatPercent(stars.LowSignalShare()),
+			stars.LowSignal, stars.Accounts)
+		if r.StargazersTruncated {
+			d.LowSignalStars += fmt.Sprintf(" (latest %d stars)", stars.Accounts)
+		}
+		d.Metrics.StargazerAccountCount = stars.Accounts
+		d.Metrics.LowSignalStarShare = stars.LowSignalShare()
+	}
+
+	return d
+}
+
+// FormatStarTrend formats the stars with an arrow of the trend.
+func FormatStarTrend(stars, trend int) string {
+	var trendEmoji string
+	switch {
+	case trend < 0:
+		trendEmoji = "⇊"
+	case trend > 0:
+		trendEmoji = "⇈"
+	}
+
+	return fmt.Sprintf("%d %s", stars, trendEmoji)
+}
+
+func formatValue(v interface{}) string {
+	ret := fmt.Sprintf("%v", v)
+	if len(ret) == 0 {
+		return "N/A"
+	}
+	return ret
+}
+
+func formatCount(v int) string {
+	if v < 0 {
+		return "N/A"
+	}
+	return strconv.Itoa(v)
+}
+
+// formatTotal formats a total count with its average per day.
+func formatTotal(v, avg int) string {
+	if v < 0 {
+		return "N/A"
+	}
+	return fmt.Sprintf("%d(%d/d)", v, avg)
+}
+
+// formatRatio formats open/total, it's N/A if both are unknown.
+func formatRatio(open, total int) string {
+	if open < 0 && total < 0 {
+		return "N/A"
+	}
+	return fmt.Sprintf("%s/%s", formatCount(open), formatCount(total))
+}
+
+func formatPercent(v float64) string {
+	return fmt.Sprintf("%.0f%%", v*100)
+}
+
+// oldestCommit returns the authored time of the oldest commit of history.
+func oldestCommit(history []AuthoredCommit) time.Time {
+	var ret time.Time
+	for _, e := range history {
+		if ret.IsZero() || e.AuthoredAt.Before(ret) {
+			ret = e.AuthoredAt
+		}
+	}
+	return ret
+}
+
+// formatSpan formats a duration in the largest fitting unit of minutes, hours
+// and days.
+func formatSpan(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%d minute(s)", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%d hour(s)", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%d day(s)", int(d.Hours()/24))
+	}
+}
+
+func formatAgeBuckets(buckets []AgeBucket) string {
+	if buckets == nil {
+		return formatValue("")
+	}
+
+	var list []string
+	for _, e := range buckets {
+		list = append(list, fmt.Sprintf("%s: %d", e.Label, e.Count))
+	}
+	return strings.Join(list, ", ")
+}
+
+// formatTimeZones formats the shares of the top 3 time zones.
+func formatTimeZones(p PunchCard) string {
+	var (
+		list  []string
+		total = p.Total()
+	)
+	for i, e := range p.TimeZones {
+		if i == 3 {
+			break
+		}
+		list = append(list, fmt.Sprintf("%s %s", e.Offset,
+			formatPercent(float64(e.Count)/float64(total))))
+	}
+	if len(list) == 0 {
+		return formatValue("")
+	}
+	return strings.Join(list, ", ")
+}
+
+// formatReleaseTrend compares the recent release interval with the earlier
+// one, a change within 20% is steady.
+func formatReleaseTrend(r ReleaseStats) string {
+	trend := r.IntervalTrend()
+	if trend == 0 {
+		return formatValue("")
+	}
+
+	var word string
+	switch {
+	case trend < 0.8:
+		word = "faster"
+	case trend > 1.25:
+		word = "slower"
+	default:
+		word = "steady"
+	}
+	return fmt.Sprintf("%s (%s recently, %s before)", word, formatSpan(r.RecentInterval),
+		formatSpan(r.EarlierInterval))
+}
+
+// formatLanguages formats the shares of the top 3 languages, the rest of them
+// are summed up as other.
+func formatLanguages(list []LanguageShare) string {
+	if len(list) == 0 {
+		return formatValue("")
+	}
+
+	var (
+		ret   []string
+		other = 100.0
+	)
+	for i, e := range list {
+		if i == 3 {
+			break
+		}
+		ret = append(ret, fmt.Sprintf("%s %.1f%%", e.Name, e.Percent))
+		other -= e.Percent
+	}
+	if other >= 0.05 {
+		ret = append(ret, fmt.Sprintf("Other %.1f%%", other))
+	}
+	return strings.Join(ret, ", ")
+}
+
+// formatProjections formats the projections one per line with their bands.
+func formatProjections(list []Projection) string {
+	var ret []string
+	for _, e := range list {
+		ret = append(ret, fmt.Sprintf("+%dd: %d (%d-%d)", e.Days, e.Value, e.Lower, e.Upper))
+	}
+	return strings.Join(ret, "\n")
+}
+
+func formatRepositoryKind(c Community) string {
+	var list []string
+	if c.Fork {
+		list = append(list, "fork")
+	}
+	if c.Template {
+		list = append(list, "template")
+	}
+	if len(list) == 0 {
+		return "source"
+	}
+	return strings.Join(list, ", ")
+}
+
+func positive(v int) int {
+	if v < 0 {
+		return 0
+	}
+	return v
+}
+
+func formatPeriod(duration time.Duration) string {
+	if duration == 0 {
+		return "N/A"
+	}
+	hours := duration.Hours()
+
+	return fmt.Sprintf("%d days", int(hours/float64(24)))
+}
+
+func formatDuration(at time.Time) string {
+	if at.IsZero() {
+		return "N/A"
+	}
+
+	duration := time.Since(at)
+	hours := int(duration.Hours())
+	minutes := int(duration.Minutes())
+
+	if hours == 0 && minutes == 0 && duration.Seconds() < float64(60) {
+		return fmt.Sprintf("%v seconds(s) ago", int(duration.Seconds()))
+	}
+	switch {
+	case hours < hour:
+		return fmt.Sprintf("%v minute(s) ago", minutes)
+	case hours < day:
+		return fmt.Sprintf("%v hour(s) ago", hours)
+	case hours < month:
+		return fmt.Sprintf("%v day(s) ago", hours/day)
+	case hours < year:
+		return fmt.Sprintf("%v month(s) ago", hours/month)
+	default:
+		return at.Format("2006-01-02")
+	}
+}
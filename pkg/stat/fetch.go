@@ -0,0 +1,142 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"context"
+	"time"
+
+	"github.com/kevwan/mapreduce/v2"
+)
+
+// Fetch fetches the statistics of repos on GitHub, the repositories which can
+// not be resolved are absent from the result. The outstanding requests are
+// stopped once ctx is done.
+func Fetch(ctx context.Context, config Config, repos ...string) []Result {
+	var (
+		windows                   = config.windows()
+		graphqlClient, restClient = newClients(config.Client)
+		repositories              = Repositories(ctx, graphqlClient, repos...)
+	)
+
+	reduce, _ := mapreduce.MapReduce(func(source chan<- *Stat) {
+		for _, r := range repos {
+			if _, ok := repositories[r]; ok {
+				source <- newStat(ctx, r, graphqlClient, restClient, windows)
+			}
+		}
+	}, func(s *Stat, writer mapreduce.Writer[Result], cancel func(error)) {
+		writer.Write(s.result(repositories[s.fullName()], config.Detail))
+	}, func(pipe <-chan Result, writer mapreduce.Writer[[]Result], cancel func(error)) {
+		var list []Result
+		for r := range pipe {
+			list = append(list, r)
+		}
+		writer.Write(list)
+	}, mapreduce.WithWorkers(len(repos)), mapreduce.WithContext(ctx))
+
+	m := make(map[string]Result, len(reduce))
+	for _, e := range reduce {
+		m[e.FullName] = e
+	}
+
+	var list []Result
+	for _, r := range repos {
+		if result, ok := m[r]; ok {
+			list = append(list, result)
+		}
+	}
+
+	return list
+}
+
+func (s Stat) result(repo Repository, detail bool) Result {
+	r := Result{
+		FullName:        s.fullName(),
+		Description:     string(repo.Description),
+		Language:        string(repo.PrimaryLanguage.Name),
+		LanguageColor:   string(repo.PrimaryLanguage.Color),
+		License:         string(repo.LicenseInfo.Name),
+		Topics:          repo.RepositoryTopics.List(),
+		CreatedAt:       repo.CreatedAt.Time,
+		PushedAt:        repo.PushedAt.Time,
+		UpdatedAt:       repo.UpdatedAt.Time,
+		LatestReleaseAt: repo.LatestRelease.PublishedAt.Time,
+		StarCount:       int(repo.StargazerCount),
+		ForkCount:       int(repo.ForkCount),
+		WatcherCount:    int(repo.Watchers.TotalCount),
+		IssueCount:      int(repo.Issues.TotalCount),
+		OpenIssueCount:  int(repo.OpenIssues.TotalCount),
+		PullCount:       int(repo.PullRequests.TotalCount),
+		OpenPullCount:   int(repo.OpenPullRequests.TotalCount),
+		ReleaseCount:    int(repo.Releases.TotalCount),
+	}
+	if repo.HomepageUrl.URL != nil {
+		r.Homepage = repo.HomepageUrl.URL.String()
+	}
+	r.Community = repo.community()
+	r.Languages = repo.Languages.shares()
+
+	mapreduce.FinishVoid(func() {
+		r.ContributorCount = s.ContributorCount()
+	}, func() {
+		stargazers, truncated := s.stargazers()
+		r.Stargazers, r.StargazersTruncated = stargazers.times(), truncated
+		r.StargazerAccounts = stargazers.accounts()
+	}, func() {
+		if detail {
+			r.Forks = s.forks().times()
+		}
+	}, func() {
+		r.Commits = s.commits().times()
+	}, func() {
+		pulls, truncated := s.pulls()
+		r.Pulls = pulls.times(time.Now().Add(-s.windows.Activity))
+		r.PullLifecycles = pulls.lifecycles(time.Now().Add(-s.windows.Lifecycle))
+		// the lifecycle window is cut only if all the pulls fall within it
+		r.PullsTruncated = truncated && len(r.PullLifecycles) == len(pulls)
+	}, func() {
+		r.OpenPulls = s.openPulls()
+	}, func() {
+		issues, truncated := s.issues()
+		if detail {
+			r.Issues = issues.times(time.Now().Add(-s.windows.Activity))
+		}
+		r.IssueLifecycles = issues.lifecycles(time.Now().Add(-s.windows.Lifecycle))
+		r.IssuesTruncated = truncated && len(r.IssueLifecycles) == len(issues)
+	}, func() {
+		r.History, r.HistoryTruncated = s.history()
+	}, func() {
+		r.Releases, r.ReleasesTruncated = s.releases()
+	}, func() {
+		r.Community.Readme = s.hasReadme()
+	}, func() {
+		r.IssuesOpened, r.IssuesClosed = Unknown, Unknown
+		opened, closed, err := s.issueFlow(time.Now().Add(-s.windows.Lifecycle))
+		if err == nil {
+			r.IssuesOpened, r.IssuesClosed = opened, closed
+		}
+	})
+
+	return r
+}
@@ -0,0 +1,119 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import "math"
+
+const (
+	// smoothingFactor is the weight of the latest day in the exponential
+	// smoothing of the daily growth.
+	smoothingFactor = 0.2
+	// confidenceZ is the z-score of the 95% confidence band.
+	confidenceZ = 1.96
+	// minSmoothingDays is the fewest days to smooth, the lifetime average is
+	// taken for shorter series.
+	minSmoothingDays = 5
+)
+
+// ForecastHorizons are the days which the counts are projected to.
+var ForecastHorizons = []int{30, 90, 365}
+
+type (
+	// Growth is the expected daily growth of a count, e.g. stars, and the
+	// standard deviation of it.
+	Growth struct {
+		Total     int     `json:"total"`
+		Rate      float64 `json:"rate"`
+		Deviation float64 `json:"deviation"`
+	}
+
+	// Projection is the projected count after Days with its 95% confidence
+	// band.
+	Projection struct {
+		Days  int `json:"days"`
+		Value int `json:"value"`
+		Lower int `json:"lower"`
+		Upper int `json:"upper"`
+	}
+)
+
+// NewGrowth fits the growth of total by the simple exponential smoothing of
+// the daily counts, the lifetime average over ageDays is taken as a Poisson
+// rate if the daily counts are too few.
+func NewGrowth(total int, daily []float64, ageDays int) Growth {
+	g := Growth{Total: total}
+	if len(daily) < minSmoothingDays {
+		if ageDays < 1 {
+			ageDays = 1
+		}
+		g.Rate = float64(positive(total)) / float64(ageDays)
+		g.Deviation = math.Sqrt(g.Rate)
+		return g
+	}
+
+	var level, squares float64
+	for _, v := range daily[:minSmoothingDays] {
+		level += v
+	}
+	level /= minSmoothingDays
+	for _, v := range daily {
+		e := v - level
+		squares += e * e
+		level += smoothingFactor * e
+	}
+	g.Rate = level
+	g.Deviation = math.Sqrt(squares / float64(len(daily)))
+	return g
+}
+
+// At projects the count after days, the daily counts are assumed to be
+// independent so the band grows with the square root of days.
+func (g Growth) At(days int) Projection {
+	var (
+		value = float64(g.Total) + g.Rate*float64(days)
+		band  = confidenceZ * g.Deviation * math.Sqrt(float64(days))
+	)
+	return Projection{
+		Days:  days,
+		Value: int(math.Round(value)),
+		Lower: int(math.Max(0, math.Round(value-band))),
+		Upper: int(math.Round(value + band)),
+	}
+}
+
+// Projections projects the count to ForecastHorizons.
+func (g Growth) Projections() []Projection {
+	var list []Projection
+	for _, e := range ForecastHorizons {
+		list = append(list, g.At(e))
+	}
+	return list
+}
+
+// completeDays drops the latest day of a chart, which is still counting.
+func completeDays(c Chart) []float64 {
+	if len(c.Data) == 0 {
+		return nil
+	}
+	return c.Data[:len(c.Data)-1]
+}
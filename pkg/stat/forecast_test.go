@@ -0,0 +1,74 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNewGrowth(t *testing.T) {
+	daily := []float64{10, 10, 10, 10, 10, 10, 10, 10}
+	g := NewGrowth(1000, daily, 100)
+	if g.Rate != 10 || g.Deviation != 0 {
+		t.Fatalf("unexpected growth: %+v", g)
+	}
+	if p := g.At(30); p.Value != 1300 || p.Lower != 1300 || p.Upper != 1300 {
+		t.Fatalf("unexpected projection: %+v", p)
+	}
+
+	g = NewGrowth(1000, []float64{0, 20, 0, 20, 0, 20, 0, 20}, 100)
+	if math.Abs(g.Rate-10) > 3 || g.Deviation == 0 {
+		t.Fatalf("unexpected growth: %+v", g)
+	}
+	list := g.Projections()
+	if len(list) != 3 || list[2].Days != 365 {
+		t.Fatalf("unexpected projections: %+v", list)
+	}
+	for i := 1; i < len(list); i++ {
+		if list[i].Upper-list[i].Lower <= list[i-1].Upper-list[i-1].Lower {
+			t.Fatalf("expected the band to widen: %+v", list)
+		}
+	}
+
+	// too few days, it's the lifetime average
+	g = NewGrowth(400, []float64{1, 2}, 100)
+	if g.Rate != 4 || g.Deviation != 2 {
+		t.Fatalf("unexpected growth: %+v", g)
+	}
+	if p := g.At(90); p.Value != 760 || p.Lower >= 760 || p.Upper <= 760 {
+		t.Fatalf("unexpected projection: %+v", p)
+	}
+}
+
+func TestDataForecast(t *testing.T) {
+	r := Result{StarCount: 365, ForkCount: Unknown, CreatedAt: time.Now().Add(-timeYear)}
+	d := NewData(r, Windows{})
+	if d.StarProjection != "+30d: 395 (384-406)\n+90d: 455 (436-474)\n+365d: 730 (693-767)" {
+		t.Fatalf("unexpected projection: %q", d.StarProjection)
+	}
+	if d.Metrics.ProjectedStarCount != 730 || d.ForkProjection != "N/A" || d.ForkForecast != nil {
+		t.Fatalf("unexpected data: %+v", d)
+	}
+}
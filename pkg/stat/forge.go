@@ -0,0 +1,241 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	GitLabPrefix = "gitlab:"
+	GiteaPrefix  = "gitea:"
+
+	defaultGitLabURL = "https://gitlab.com"
+	defaultGiteaURL  = "https://codeberg.org"
+
+	forgeMaxPages = 10
+)
+
+type (
+	forgeClient struct {
+		baseURL string
+		header  http.Header
+		client  *http.Client
+		windows Windows
+		ctx     context.Context
+	}
+)
+
+// IsExternal reports whether repo is not hosted on GitHub.
+func IsExternal(repo string) bool {
+	if IsLocal(repo) {
+		return true
+	}
+	_, _, _, err := parseForge(repo)
+	return err == nil
+}
+
+// FetchExternal fetches the statistics of a repository which is on disk,
+// GitLab or Gitea.
+func FetchExternal(ctx context.Context, config Config, repo string) (result Result, err error) {
+	reportPage(ctx, repo, StageMetadata, 1)
+	defer func() {
+		reportDone(ctx, repo, StageMetadata, err)
+	}()
+
+	if IsLocal(repo) {
+		return Local(ctx, config.windows(), repo)
+	}
+
+	kind, baseURL, path, err := parseForge(repo)
+	if err != nil {
+		return Result{}, err
+	}
+
+	switch kind {
+	case GitLabPrefix:
+		result, err = newForgeClient(ctx, config, baseURL, "PRIVATE-TOKEN",
+			config.gitlabToken(), "").gitlab(path)
+	default:
+		result, err = newForgeClient(ctx, config, baseURL, "Authorization",
+			config.giteaToken(), "token ").gitea(path)
+	}
+	if err != nil {
+		return Result{}, fmt.Errorf("%s: %w", repo, err)
+	}
+
+	result.FullName = repo
+	return result, nil
+}
+
+// parseForge parses gitlab:group/project, gitea:owner/repo, a prefixed url
+// like gitlab:https://git.example.com/group/project or a url of gitlab.com
+// and codeberg.org.
+func parseForge(repo string) (kind, baseURL, path string, err error) {
+	switch {
+	case strings.HasPrefix(repo, GitLabPrefix):
+		kind, baseURL = GitLabPrefix, defaultGitLabURL
+	case strings.HasPrefix(repo, GiteaPrefix):
+		kind, baseURL = GiteaPrefix, defaultGiteaURL
+	}
+	rest := strings.TrimPrefix(strings.TrimPrefix(repo, GitLabPrefix), GiteaPrefix)
+
+	if strings.HasPrefix(rest, "http://") || strings.HasPrefix(rest, "https://") {
+		u, err := url.Parse(rest)
+		if err != nil {
+			return "", "", "", err
+		}
+		baseURL = u.Scheme + "://" + u.Host
+		rest = u.Path
+		if len(kind) == 0 {
+			switch u.Host {
+			case "gitlab.com":
+				kind = GitLabPrefix
+			case "codeberg.org", "gitea.com":
+				kind = GiteaPrefix
+			}
+		}
+	}
+
+	path = strings.TrimSuffix(strings.Trim(rest, "/"), ".git")
+	if len(kind) == 0 {
+		return "", "", "", fmt.Errorf("unknown forge of %q", repo)
+	}
+	if strings.Count(path, "/") < 1 || (kind == GiteaPrefix && strings.Count(path, "/") != 1) {
+		return "", "", "", fmt.Errorf("invalid repository path of %q", repo)
+	}
+
+	return kind, baseURL, path, nil
+}
+
+func newForgeClient(ctx context.Context, config Config, baseURL, tokenHeader, token,
+	tokenPrefix string) forgeClient {
+	header := http.Header{}
+	if len(token) > 0 {
+		header.Set(tokenHeader, tokenPrefix+token)
+	}
+	return forgeClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		header:  header,
+		client:  &http.Client{Transport: config.Transport, Timeout: 30 * time.Second},
+		windows: config.windows(),
+		ctx:     ctx,
+	}
+}
+
+func (c forgeClient) get(path string, query url.Values, v interface{}) (http.Header, error) {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(c.ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = c.header.Clone()
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", u, resp.Status)
+	}
+	if v == nil {
+		return resp.Header, nil
+	}
+
+	return resp.Header, json.NewDecoder(resp.Body).Decode(v)
+}
+
+// total returns the total count in header key by requesting a single item.
+func (c forgeClient) total(path string, query url.Values, sizeParam, key string) int {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = v
+	}
+	q.Set(sizeParam, "1")
+
+	header, err := c.get(path, q, nil)
+	if err != nil {
+		return Unknown
+	}
+
+	return c.totalOf(header, key)
+}
+
+func (c forgeClient) totalOf(header http.Header, key string) int {
+	total, err := strconv.Atoi(header.Get(key))
+	if err != nil {
+		return Unknown
+	}
+	return total
+}
+
+// dates pages through a list and collects the time of field until deadline,
+// the list must be in descending order of field. It returns nil if the list is
+// unknown.
+func (c forgeClient) dates(path string, query url.Values, sizeParam, field string,
+	deadline time.Time) timeList {
+	const size = 50
+	list := timeList{}
+	for page := 1; page <= forgeMaxPages; page++ {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		q.Set("page", strconv.Itoa(page))
+		q.Set(sizeParam, strconv.Itoa(size))
+
+		var items []map[string]json.RawMessage
+		if _, err := c.get(path, q, &items); err != nil {
+			return nil
+		}
+
+		for _, e := range items {
+			var t time.Time
+			if err := json.Unmarshal(e[field], &t); err != nil {
+				continue
+			}
+			if t.Before(deadline) {
+				return list
+			}
+			list = append(list, t)
+		}
+		if len(items) < size {
+			break
+		}
+	}
+
+	return list
+}
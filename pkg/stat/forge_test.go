@@ -0,0 +1,190 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseForge(t *testing.T) {
+	for _, e := range []struct {
+		repo    string
+		kind    string
+		baseURL string
+		path    string
+	}{
+		{"gitlab:gitlab-org/gitlab-runner", GitLabPrefix, defaultGitLabURL, "gitlab-org/gitlab-runner"},
+		{"https://gitlab.com/group/sub/project.git", GitLabPrefix, defaultGitLabURL, "group/sub/project"},
+		{"gitea:forgejo/forgejo", GiteaPrefix, defaultGiteaURL, "forgejo/forgejo"},
+		{"https://codeberg.org/forgejo/forgejo", GiteaPrefix, defaultGiteaURL, "forgejo/forgejo"},
+		{"gitea:https://git.example.com/owner/repo/", GiteaPrefix, "https://git.example.com", "owner/repo"},
+	} {
+		kind, baseURL, path, err := parseForge(e.repo)
+		if err != nil {
+			t.Fatalf("parse %s: %v", e.repo, err)
+		}
+		if kind != e.kind || baseURL != e.baseURL || path != e.path {
+			t.Fatalf("parse %s: got %s %s %s", e.repo, kind, baseURL, path)
+		}
+	}
+
+	for _, e := range []string{"spf13/cobra", "https://git.example.com/owner/repo", "gitea:a/b/c"} {
+		if _, _, _, err := parseForge(e); err == nil {
+			t.Fatalf("expected error of %s", e)
+		}
+	}
+}
+
+func TestFetchGitLab(t *testing.T) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	mux := http.NewServeMux()
+	var token string
+	mux.HandleFunc("/api/v4/projects/group/project", func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("PRIVATE-TOKEN")
+		fmt.Fprintf(w, `{"description":"desc","web_url":"https://gitlab.com/group/project",
+"star_count":100,"forks_count":10,"created_at":"2020-01-01T00:00:00Z","last_activity_at":"%s",
+"topics":["go"],"license":{"name":"MIT License"}}`, now)
+	})
+	mux.HandleFunc("/api/v4/projects/group/project/issues_statistics", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"statistics":{"counts":{"all":20,"closed":15,"opened":5}}}`)
+	})
+	mux.HandleFunc("/api/v4/projects/group/project/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(gitlabTotalHeader, "7")
+		fmt.Fprintf(w, `[{"created_at":"%s"}]`, now)
+	})
+	mux.HandleFunc("/api/v4/projects/group/project/repository/contributors", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(gitlabTotalHeader, "3")
+		fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("/api/v4/projects/group/project/releases", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(gitlabTotalHeader, "4")
+		fmt.Fprintf(w, `[{"released_at":"%s"}]`, now)
+	})
+	mux.HandleFunc("/api/v4/projects/group/project/languages", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"Go":90.5,"Shell":9.5}`)
+	})
+	mux.HandleFunc("/api/v4/projects/group/project/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"created_at":"%s"},{"created_at":"%s"}]`, now, now)
+	})
+	mux.HandleFunc("/api/v4/projects/group/project/issues", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+	svr := httptest.NewServer(mux)
+	defer svr.Close()
+
+	repo := GitLabPrefix + svr.URL + "/group/project"
+	if !IsExternal(repo) {
+		t.Fatalf("expected %s to be external", repo)
+	}
+
+	r, err := FetchExternal(context.Background(), Config{GitLabToken: "glpat"}, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "glpat" {
+		t.Fatalf("unexpected token: %q", token)
+	}
+	data := NewData(r, Windows{})
+	m := data.Metrics
+	if data.FullName != repo || data.Language != "Go" || data.License != "MIT License" ||
+		data.Issue != "5/20" || data.Pull != "7/7" || data.WatcherCount != "N/A" ||
+		data.LanguageBreakdown != "Go 90.5%, Shell 9.5%" {
+		t.Fatalf("unexpected data: %+v", data)
+	}
+	if m.StarCount != 100 || m.ContributorCount != 3 || m.ReleaseCount != 4 ||
+		m.LatestWeekCommitCount != 2 || m.LatestWeekPullCount != 1 || !m.HasLicense {
+		t.Fatalf("unexpected metrics: %+v", m)
+	}
+}
+
+func TestFetchGitea(t *testing.T) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/repos/owner/repo", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"description":"desc","html_url":"https://codeberg.org/owner/repo",
+"stars_count":50,"forks_count":5,"watchers_count":3,"open_issues_count":2,"open_pr_counter":1,
+"release_counter":6,"language":"Go","licenses":["MIT"],"created_at":"2021-01-01T00:00:00Z",
+"updated_at":"%s"}`, now)
+	})
+	mux.HandleFunc("/api/v1/repos/owner/repo/issues", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(giteaTotalHeader, "10")
+		fmt.Fprintf(w, `[{"created_at":"%s"}]`, now)
+	})
+	var pullSort string
+	mux.HandleFunc("/api/v1/repos/owner/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "1" {
+			pullSort = r.URL.Query().Get("sort")
+		}
+		w.Header().Set(giteaTotalHeader, "4")
+		fmt.Fprint(w, `[{"created_at":"2021-01-01T00:00:00Z"}]`)
+	})
+	mux.HandleFunc("/api/v1/repos/owner/repo/releases", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"published_at":"%s"}]`, now)
+	})
+	mux.HandleFunc("/api/v1/repos/owner/repo/commits", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"created":"%s"}]`, now)
+	})
+	svr := httptest.NewServer(mux)
+	defer svr.Close()
+
+	r, err := FetchExternal(context.Background(), Config{}, GiteaPrefix+svr.URL+"/owner/repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := NewData(r, Windows{})
+	m := data.Metrics
+	if data.Issue != "2/10" || data.Pull != "1/4" || data.ContributorCount != "N/A" ||
+		data.License != "MIT" || data.Homepage != "https://codeberg.org/owner/repo" {
+		t.Fatalf("unexpected data: %+v", data)
+	}
+	if m.StarCount != 50 || m.ReleaseCount != 6 || m.LatestWeekCommitCount != 1 ||
+		m.LatestWeekPullCount != 0 || m.LatestReleaseAt.IsZero() {
+		t.Fatalf("unexpected metrics: %+v", m)
+	}
+	if pullSort != "newest" {
+		t.Fatalf("unexpected sort of pulls: %q", pullSort)
+	}
+}
+
+func TestFetchGiteaUnknown(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/repos/owner/repo", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"stars_count":50,"created_at":"2021-01-01T00:00:00Z"}`)
+	})
+	svr := httptest.NewServer(mux)
+	defer svr.Close()
+
+	r, err := FetchExternal(context.Background(), Config{}, GiteaPrefix+svr.URL+"/owner/repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Commits != nil || r.Pulls != nil || r.Issues != nil || !r.LatestReleaseAt.IsZero() ||
+		r.IssueCount != Unknown || r.PullCount != Unknown {
+		t.Fatalf("unexpected result: %+v", r)
+	}
+}
@@ -25,7 +25,6 @@ package stat
 import (
 	"time"
 
-	"github.com/anqiansong/github-compare/pkg/timex"
 	"github.com/shurcooL/githubv4"
 )
 
@@ -56,45 +55,21 @@ type (
 	}
 )
 
-func (f Forks) Chart() Chart {
-	var (
-		labels  []string
-		data    []float64
-		now     = time.Now()
-		dayTime = timex.AllDays(now.Add(-weekDur), now)
-	)
-
-	for _, t := range dayTime {
-		label := t.Format(labelLayout)
-		labels = append(labels, label)
-		data = append(data, float64(f.getSpecifiedDate(t)))
-	}
-
-	return Chart{Data: data, Labels: labels}
-}
-
-func (f Forks) getSpecifiedDate(date time.Time) int {
-	var (
-		count int
-		zero  = timex.Truncate(date)
-	)
-
+func (f Forks) times() timeList {
+	list := make(timeList, 0, len(f))
 	for _, e := range f {
-		if timex.Truncate(e.Node.CreatedAt.Time).Equal(zero) {
-			count += 1
-		}
+		list = append(list, e.Node.CreatedAt.Time)
 	}
-
-	return count
+	return list
 }
 
-func (s Stat) latestWeekForks() Forks {
+func (s Stat) forks() Forks {
 	var (
 		list      Forks
 		brk       bool
 		forkQuery ForkQuery
 		after     githubv4.String
-		deadline  = time.Now().Add(-timeWeek)
+		deadline  = time.Now().Add(-s.windows.Activity)
 	)
 
 	arg := map[string]interface{}{
@@ -107,8 +82,12 @@ func (s Stat) latestWeekForks() Forks {
 		},
 	}
 
-	for {
-		_ = s.graphqlClient.Query(s.ctx, &forkQuery, arg)
+	for page := 1; ; page++ {
+		s.reportPage(StageForks, page)
+		if err := s.graphqlClient.Query(s.ctx, &forkQuery, arg); err != nil {
+			s.reportDone(StageForks, err)
+			return list
+		}
 		temp := forkQuery.Forks.List.Edges
 		for _, e := range temp {
 			if e.Node.CreatedAt.Time.Before(deadline) {
@@ -126,5 +105,6 @@ func (s Stat) latestWeekForks() Forks {
 		arg["after"] = after
 	}
 
+	s.reportDone(StageForks, nil)
 	return list
 }
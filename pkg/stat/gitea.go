@@ -0,0 +1,117 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/kevwan/mapreduce/v2"
+)
+
+const giteaTotalHeader = "X-Total-Count"
+
+type (
+	giteaRepository struct {
+		Description     string    `json:"description"`
+		Website         string    `json:"website"`
+		HTMLURL         string    `json:"html_url"`
+		StarsCount      int       `json:"stars_count"`
+		ForksCount      int       `json:"forks_count"`
+		WatchersCount   int       `json:"watchers_count"`
+		OpenIssuesCount int       `json:"open_issues_count"`
+		OpenPRCounter   int       `json:"open_pr_counter"`
+		ReleaseCounter  int       `json:"release_counter"`
+		Language        string    `json:"language"`
+		Topics          []string  `json:"topics"`
+		Licenses        []string  `json:"licenses"`
+		CreatedAt       time.Time `json:"created_at"`
+		UpdatedAt       time.Time `json:"updated_at"`
+	}
+
+	giteaRelease struct {
+		PublishedAt time.Time `json:"published_at"`
+	}
+)
+
+// gitea fetches a repository by the Gitea(and Forgejo) REST API v1, the API
+// does not provide the contributors.
+func (c forgeClient) gitea(path string) (Result, error) {
+	var (
+		repo     giteaRepository
+		prefix   = "/api/v1/repos/" + path
+		deadline = time.Now().Add(-c.windows.Activity)
+		since    = deadline.UTC().Format(time.RFC3339)
+		r        = Result{ContributorCount: Unknown}
+		releases []giteaRelease
+	)
+
+	if _, err := c.get(prefix, nil, &repo); err != nil {
+		return r, err
+	}
+
+	mapreduce.FinishVoid(func() {
+		r.IssueCount = c.total(prefix+"/issues", url.Values{"state": {"all"}, "type": {"issues"}},
+			"limit", giteaTotalHeader)
+	}, func() {
+		r.PullCount = c.total(prefix+"/pulls", url.Values{"state": {"all"}}, "limit",
+			giteaTotalHeader)
+	}, func() {
+		_, err := c.get(prefix+"/releases", url.Values{"limit": {"1"}}, &releases)
+		if err == nil && len(releases) > 0 {
+			r.LatestReleaseAt = releases[0].PublishedAt
+		}
+	}, func() {
+		r.Commits = c.dates(prefix+"/commits", url.Values{"since": {since}}, "limit",
+			"created", deadline)
+	}, func() {
+		r.Pulls = c.dates(prefix+"/pulls", url.Values{"state": {"all"}, "sort": {"newest"}},
+			"limit", "created_at", deadline)
+	}, func() {
+		r.Issues = c.dates(prefix+"/issues", url.Values{
+			"state": {"all"}, "type": {"issues"},
+		}, "limit", "created_at", deadline)
+	})
+
+	r.Homepage = repo.Website
+	if len(r.Homepage) == 0 {
+		r.Homepage = repo.HTMLURL
+	}
+	r.Description = repo.Description
+	r.Language = repo.Language
+	r.Topics = repo.Topics
+	if len(repo.Licenses) > 0 {
+		r.License = repo.Licenses[0]
+	}
+	r.StarCount = repo.StarsCount
+	r.ForkCount = repo.ForksCount
+	r.WatcherCount = repo.WatchersCount
+	r.OpenIssueCount = repo.OpenIssuesCount
+	r.OpenPullCount = repo.OpenPRCounter
+	r.ReleaseCount = repo.ReleaseCounter
+	r.CreatedAt = repo.CreatedAt
+	r.PushedAt = repo.UpdatedAt
+	r.UpdatedAt = repo.UpdatedAt
+
+	return r, nil
+}
@@ -0,0 +1,153 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/kevwan/mapreduce/v2"
+)
+
+const gitlabTotalHeader = "X-Total"
+
+type (
+	gitlabProject struct {
+		Description    string    `json:"description"`
+		WebURL         string    `json:"web_url"`
+		StarCount      int       `json:"star_count"`
+		ForksCount     int       `json:"forks_count"`
+		CreatedAt      time.Time `json:"created_at"`
+		LastActivityAt time.Time `json:"last_activity_at"`
+		Topics         []string  `json:"topics"`
+		TagList        []string  `json:"tag_list"`
+		License        *struct {
+			Name string `json:"name"`
+		} `json:"license"`
+	}
+
+	gitlabIssueStatistics struct {
+		Statistics struct {
+			Counts struct {
+				All    int `json:"all"`
+				Opened int `json:"opened"`
+			} `json:"counts"`
+		} `json:"statistics"`
+	}
+
+	gitlabRelease struct {
+		ReleasedAt time.Time `json:"released_at"`
+	}
+)
+
+// gitlab fetches a project by the GitLab REST API v4.
+func (c forgeClient) gitlab(path string) (Result, error) {
+	var (
+		project   gitlabProject
+		prefix    = "/api/v4/projects/" + url.PathEscape(path)
+		deadline  = time.Now().Add(-c.windows.Activity)
+		since     = deadline.UTC().Format(time.RFC3339)
+		r         = Result{WatcherCount: Unknown}
+		issues    gitlabIssueStatistics
+		languages map[string]float64
+		releases  []gitlabRelease
+	)
+
+	if _, err := c.get(prefix, url.Values{"license": {"true"}}, &project); err != nil {
+		return r, err
+	}
+
+	mapreduce.FinishVoid(func() {
+		if _, err := c.get(prefix+"/issues_statistics", nil, &issues); err != nil {
+			r.IssueCount, r.OpenIssueCount = Unknown, Unknown
+			return
+		}
+		r.IssueCount = issues.Statistics.Counts.All
+		r.OpenIssueCount = issues.Statistics.Counts.Opened
+	}, func() {
+		r.OpenPullCount = c.total(prefix+"/merge_requests", url.Values{"state": {"opened"}},
+			"per_page", gitlabTotalHeader)
+	}, func() {
+		r.PullCount = c.total(prefix+"/merge_requests", url.Values{"state": {"all"}}, "per_page",
+			gitlabTotalHeader)
+	}, func() {
+		r.ContributorCount = c.total(prefix+"/repository/contributors", nil, "per_page",
+			gitlabTotalHeader)
+	}, func() {
+		header, err := c.get(prefix+"/releases", url.Values{"per_page": {"1"}}, &releases)
+		if err != nil {
+			r.ReleaseCount = Unknown
+			return
+		}
+		r.ReleaseCount = c.totalOf(header, gitlabTotalHeader)
+		if len(releases) > 0 {
+			r.LatestReleaseAt = releases[0].ReleasedAt
+		}
+	}, func() {
+		if _, err := c.get(prefix+"/languages", nil, &languages); err != nil {
+			languages = nil
+		}
+	}, func() {
+		r.Commits = c.dates(prefix+"/repository/commits", url.Values{"since": {since}},
+			"per_page", "created_at", deadline)
+	}, func() {
+		r.Pulls = c.dates(prefix+"/merge_requests", url.Values{
+			"state": {"all"}, "created_after": {since}, "order_by": {"created_at"},
+		}, "per_page", "created_at", deadline)
+	}, func() {
+		r.Issues = c.dates(prefix+"/issues", url.Values{
+			"created_after": {since}, "order_by": {"created_at"},
+		}, "per_page", "created_at", deadline)
+	})
+
+	for k, v := range languages {
+		r.Languages = append(r.Languages, LanguageShare{Name: k, Percent: v})
+	}
+	sort.Slice(r.Languages, func(i, j int) bool {
+		if r.Languages[i].Percent != r.Languages[j].Percent {
+			return r.Languages[i].Percent > r.Languages[j].Percent
+		}
+		return r.Languages[i].Name < r.Languages[j].Name
+	})
+	if len(r.Languages) > 0 {
+		r.Language = r.Languages[0].Name
+	}
+
+	r.Homepage = project.WebURL
+	r.Description = project.Description
+	r.Topics = project.Topics
+	if len(r.Topics) == 0 {
+		r.Topics = project.TagList
+	}
+	if project.License != nil {
+		r.License = project.License.Name
+	}
+	r.StarCount = project.StarCount
+	r.ForkCount = project.ForksCount
+	r.CreatedAt = project.CreatedAt
+	r.PushedAt = project.LastActivityAt
+	r.UpdatedAt = project.LastActivityAt
+
+	return r, nil
+}
@@ -0,0 +1,155 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// maxHistoryPages limits the history to the latest 1000 commits.
+const maxHistoryPages = 10
+
+// freeMailDomains are the email domains which don't imply an organization.
+var freeMailDomains = map[string]struct{}{
+	"gmail.com": {}, "googlemail.com": {}, "outlook.com": {}, "hotmail.com": {},
+	"live.com": {}, "yahoo.com": {}, "icloud.com": {}, "me.com": {}, "qq.com": {},
+	"163.com": {}, "126.com": {}, "foxmail.com": {}, "protonmail.com": {},
+	"proton.me": {}, "localhost": {},
+}
+
+type (
+	HistoryUser struct {
+		Login   githubv4.String
+		Company githubv4.String
+	}
+
+	HistoryAuthor struct {
+		Email githubv4.String
+		Name  githubv4.String
+		User  *HistoryUser
+	}
+
+	HistoryCommit struct {
+		AuthoredDate githubv4.GitTimestamp
+		Author       HistoryAuthor
+	}
+
+	HistoryConnection struct {
+		Nodes    []HistoryCommit
+		PageInfo PageInfo
+	}
+
+	HistoryQuery struct {
+		Repository struct {
+			DefaultBranchRef struct {
+				Target struct {
+					Commit struct {
+						History HistoryConnection `graphql:"history(first: 100, since: $since, after: $after)"`
+					} `graphql:"... on Commit"`
+				}
+			}
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+)
+
+func (c HistoryCommit) authored() AuthoredCommit {
+	var (
+		author  = strings.ToLower(string(c.Author.Email))
+		email   = author
+		company string
+	)
+	if c.Author.User != nil {
+		author = strings.ToLower(string(c.Author.User.Login))
+		company = string(c.Author.User.Company)
+	}
+	if len(author) == 0 {
+		author = string(c.Author.Name)
+	}
+
+	return AuthoredCommit{
+		Author:       author,
+		Organization: organization(company, email),
+		AuthoredAt:   c.AuthoredDate.Time,
+	}
+}
+
+// organization derives the organization of an author from the company of the
+// profile, or the domain of the email if it's not a free mail.
+func organization(company, email string) string {
+	company = strings.ToLower(strings.Trim(company, "@ \t"))
+	if len(company) > 0 {
+		return company
+	}
+
+	i := strings.LastIndex(email, "@")
+	if i < 0 {
+		return ""
+	}
+	domain := strings.ToLower(email[i+1:])
+	if _, ok := freeMailDomains[domain]; ok || strings.HasSuffix(domain, "noreply.github.com") {
+		return ""
+	}
+	return domain
+}
+
+// history fetches the latest commits on the default branch within
+// Windows.History, truncated tells whether there are more commits than
+// maxHistoryPages within the window.
+func (s Stat) history() (list []AuthoredCommit, truncated bool) {
+	var query HistoryQuery
+
+	arg := map[string]interface{}{
+		"after": (*githubv4.String)(nil),
+		"owner": githubv4.String(s.owner),
+		"name":  githubv4.String(s.repo),
+		"since": githubv4.GitTimestamp{Time: time.Now().Add(-s.windows.History)},
+	}
+
+	for page := 1; page <= maxHistoryPages; page++ {
+		s.reportPage(StageHistory, page)
+		if err := s.graphqlClient.Query(s.ctx, &query, arg); err != nil {
+			s.reportDone(StageHistory, err)
+			return nil, false
+		}
+
+		conn := query.Repository.DefaultBranchRef.Target.Commit.History
+		if list == nil {
+			list = make([]AuthoredCommit, 0, len(conn.Nodes))
+		}
+		for _, e := range conn.Nodes {
+			list = append(list, e.authored())
+		}
+		if !(bool)(conn.PageInfo.HasNextPage) {
+			break
+		}
+		truncated = page == maxHistoryPages
+
+		arg["after"] = githubv4.NewString(conn.PageInfo.EndCursor)
+	}
+
+	s.reportDone(StageHistory, nil)
+	return list, truncated
+}
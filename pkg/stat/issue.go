@@ -23,9 +23,10 @@
 package stat
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
-	"github.com/anqiansong/github-compare/pkg/timex"
 	"github.com/shurcooL/githubv4"
 )
 
@@ -44,7 +45,12 @@ type (
 	}
 
 	Issue struct {
-		CreatedAt githubv4.DateTime
+		CreatedAt         githubv4.DateTime
+		ClosedAt          githubv4.DateTime
+		AuthorAssociation githubv4.String
+		Author            Actor
+		Comments          ResponseConnection `graphql:"comments(first: 10)"`
+		Labels            LabelConnection    `graphql:"labels(first: 10)"`
 	}
 
 	Issues struct {
@@ -54,64 +60,95 @@ type (
 	IssueQuery struct {
 		Issue Issues `graphql:"repository(owner: $owner, name: $name)"`
 	}
+
+	IssueCount struct {
+		IssueCount githubv4.Int
+	}
+
+	IssueFlowQuery struct {
+		Opened IssueCount `graphql:"opened: search(query: $opened, type: ISSUE)"`
+		Closed IssueCount `graphql:"closed: search(query: $closed, type: ISSUE)"`
+	}
 )
 
-func (i IssueList) Chart() Chart {
-	var (
-		labels  []string
-		data    []float64
-		now     = time.Now()
-		dayTime = timex.AllDays(now.Add(-weekDur), now)
-	)
+// maxIssuePages limits the issues within the windows to the newest 1000.
+const maxIssuePages = 10
 
-	for _, t := range dayTime {
-		label := t.Format(labelLayout)
-		labels = append(labels, label)
-		data = append(data, float64(i.getSpecifiedDate(t)))
+// times returns the creation times of the issues since since, it's nil if the
+// issues are unknown.
+func (i IssueList) times(since time.Time) timeList {
+	if i == nil {
+		return nil
 	}
 
-	return Chart{Data: data, Labels: labels}
+	list := make(timeList, 0, len(i))
+	for _, e := range i {
+		if e.Node.CreatedAt.Time.After(since) {
+			list = append(list, e.Node.CreatedAt.Time)
+		}
+	}
+	return list
 }
 
-func (i IssueList) getSpecifiedDate(date time.Time) int {
-	var (
-		count int
-		zero  = timex.Truncate(date)
-	)
+// lifecycles returns the lifecycles of the issues created since since, it's
+// nil if the issues are unknown.
+func (i IssueList) lifecycles(since time.Time) []IssueLifecycle {
+	if i == nil {
+		return nil
+	}
 
+	list := make([]IssueLifecycle, 0, len(i))
 	for _, e := range i {
-		if timex.Truncate(e.Node.CreatedAt.Time).Equal(zero) {
-			count += 1
+		n := e.Node
+		if !n.CreatedAt.Time.After(since) {
+			continue
 		}
+		list = append(list, IssueLifecycle{
+			CreatedAt:       n.CreatedAt.Time,
+			ClosedAt:        n.ClosedAt.Time,
+			FirstResponseAt: firstMaintainerResponse(n.Author, n.Comments),
+			Stale:           !n.ClosedAt.Time.IsZero() && hasStaleLabel(n.Labels),
+		})
 	}
+	return list
+}
 
-	return count
+func hasStaleLabel(labels LabelConnection) bool {
+	for _, e := range labels.Nodes {
+		if strings.Contains(strings.ToLower(string(e.Name)), "stale") {
+			return true
+		}
+	}
+	return false
 }
 
-func (s Stat) OpenIssueCount() githubv4.Int {
-	var issueQuery IssueQuery
-	_ = s.graphqlClient.Query(s.ctx, &issueQuery, map[string]interface{}{
-		"after":       (*githubv4.String)(nil),
-		"owner":       githubv4.String(s.owner),
-		"name":        githubv4.String(s.repo),
-		"first":       1,
-		"issueStates": []githubv4.IssueState{githubv4.IssueStateOpen},
-		"orderBy": githubv4.IssueOrder{
-			Field:     githubv4.IssueOrderFieldCreatedAt,
-			Direction: githubv4.OrderDirectionDesc,
-		},
+// issueFlow returns the numbers of the issues opened and closed since since.
+func (s Stat) issueFlow(since time.Time) (opened, closed int, err error) {
+	var (
+		query IssueFlowQuery
+		date  = since.UTC().Format("2006-01-02")
+		repo  = fmt.Sprintf("repo:%s is:issue", s.fullName())
+	)
+	err = s.graphqlClient.Query(s.ctx, &query, map[string]interface{}{
+		"opened": githubv4.String(fmt.Sprintf("%s created:>=%s", repo, date)),
+		"closed": githubv4.String(fmt.Sprintf("%s closed:>=%s", repo, date)),
 	})
+	if err != nil {
+		return 0, 0, err
+	}
 
-	return issueQuery.Issue.List.TotalCount
+	return int(query.Opened.IssueCount), int(query.Closed.IssueCount), nil
 }
 
-func (s Stat) LatestWeekIssues() IssueList {
+// issues fetches at most maxIssuePages of the issues created within the
+// longest window, it's nil if the query fails. truncated tells whether there
+// are more issues within the window.
+func (s Stat) issues() (list IssueList, truncated bool) {
 	var (
-		list       IssueList
 		brk        bool
 		issueQuery IssueQuery
 		after      githubv4.String
-		deadline   = time.Now().Add(-timeWeek)
+		deadline   = time.Now().Add(-s.windows.longest())
 	)
 
 	arg := map[string]interface{}{
@@ -126,9 +163,16 @@ func (s Stat) LatestWeekIssues() IssueList {
 		},
 	}
 
-	for {
-		_ = s.graphqlClient.Query(s.ctx, &issueQuery, arg)
+	for page := 1; page <= maxIssuePages; page++ {
+		s.reportPage(StageIssues, page)
+		if err := s.graphqlClient.Query(s.ctx, &issueQuery, arg); err != nil {
+			s.reportDone(StageIssues, err)
+			return nil, false
+		}
 		temp := issueQuery.Issue.List.Edges
+		if list == nil {
+			list = make(IssueList, 0, len(temp))
+		}
 
 		for _, e := range temp {
 			if e.Node.CreatedAt.Time.Before(deadline) {
@@ -140,10 +184,12 @@ func (s Stat) LatestWeekIssues() IssueList {
 		if brk || !(bool)(issueQuery.Issue.List.PageInfo.HasNextPage) || len(temp) == 0 {
 			break
 		}
+		truncated = page == maxIssuePages
 
 		after = temp[len(temp)-1].Cursor
 		arg["after"] = after
 	}
 
-	return list
+	s.reportDone(StageIssues, nil)
+	return list, truncated
 }
@@ -26,7 +26,13 @@ import "github.com/shurcooL/githubv4"
 
 type (
 	LanguageConnection struct {
-		Nodes []Language
+		TotalSize githubv4.Int
+		Edges     []LanguageEdge
+	}
+
+	LanguageEdge struct {
+		Size githubv4.Int
+		Node Language
 	}
 
 	Language struct {
@@ -34,3 +40,17 @@ type (
 		Name  githubv4.String
 	}
 )
+
+// shares returns the languages with their percentages of the total size.
+func (l LanguageConnection) shares() []LanguageShare {
+	list := make([]LanguageShare, 0, len(l.Edges))
+	for _, e := range l.Edges {
+		share := LanguageShare{Name: string(e.Node.Name), Color: string(e.Node.Color),
+			Bytes: int(e.Size)}
+		if l.TotalSize > 0 {
+			share.Percent = float64(e.Size) / float64(l.TotalSize) * 100
+		}
+		list = append(list, share)
+	}
+	return list
+}
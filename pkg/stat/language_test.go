@@ -0,0 +1,51 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import "testing"
+
+func TestLanguageShares(t *testing.T) {
+	conn := LanguageConnection{TotalSize: 1000, Edges: []LanguageEdge{
+		{Size: 800, Node: Language{Name: "Go", Color: "#00ADD8"}},
+		{Size: 100, Node: Language{Name: "Shell", Color: "#89e051"}},
+		{Size: 50, Node: Language{Name: "Makefile", Color: "#427819"}},
+		{Size: 30, Node: Language{Name: "Dockerfile", Color: "#384d54"}},
+	}}
+
+	list := conn.shares()
+	if len(list) != 4 || list[0].Name != "Go" || list[0].Color != "#00ADD8" ||
+		list[0].Bytes != 800 || list[0].Percent != 80 {
+		t.Fatalf("unexpected shares: %+v", list)
+	}
+
+	d := NewData(Result{Languages: list}, Windows{})
+	if d.LanguageBreakdown != "Go 80.0%, Shell 10.0%, Makefile 5.0%, Other 5.0%" {
+		t.Fatalf("unexpected breakdown: %s", d.LanguageBreakdown)
+	}
+	if len(d.Languages) != 4 {
+		t.Fatalf("unexpected languages: %+v", d.Languages)
+	}
+	if d := NewData(Result{}, Windows{}); d.LanguageBreakdown != "N/A" {
+		t.Fatalf("unexpected breakdown: %s", d.LanguageBreakdown)
+	}
+}
@@ -0,0 +1,191 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"sort"
+	"time"
+)
+
+type (
+	// PullStats summarizes the lifecycles of pull requests.
+	PullStats struct {
+		Count                     int           `json:"count"`
+		Merged                    int           `json:"merged"`
+		ClosedUnmerged            int           `json:"closedUnmerged"`
+		Responded                 int           `json:"responded"`
+		FirstTimeContributors     int           `json:"firstTimeContributors"`
+		MedianTimeToMerge         time.Duration `json:"medianTimeToMerge"`
+		P90TimeToMerge            time.Duration `json:"p90TimeToMerge"`
+		MedianTimeToFirstResponse time.Duration `json:"medianTimeToFirstResponse"`
+		OpenAges                  []AgeBucket   `json:"openAges"`
+	}
+
+	// IssueStats summarizes the lifecycles of issues.
+	IssueStats struct {
+		Count                     int           `json:"count"`
+		Closed                    int           `json:"closed"`
+		Stale                     int           `json:"stale"`
+		Responded                 int           `json:"responded"`
+		MedianTimeToFirstResponse time.Duration `json:"medianTimeToFirstResponse"`
+		MedianTimeToClose         time.Duration `json:"medianTimeToClose"`
+	}
+
+	// AgeBucket counts the items whose age is less than Max, the zero Max
+	// means unbounded.
+	AgeBucket struct {
+		Label string        `json:"label"`
+		Max   time.Duration `json:"max"`
+		Count int           `json:"count"`
+	}
+)
+
+// NewPullStats summarizes pulls and the creation times of the open pull
+// requests.
+func NewPullStats(pulls []PullLifecycle, openPulls []time.Time) PullStats {
+	var (
+		ret       = PullStats{Count: len(pulls)}
+		merges    []time.Duration
+		responses []time.Duration
+	)
+
+	for _, e := range pulls {
+		switch {
+		case !e.MergedAt.IsZero():
+			ret.Merged++
+			merges = append(merges, e.MergedAt.Sub(e.CreatedAt))
+		case !e.ClosedAt.IsZero():
+			ret.ClosedUnmerged++
+		}
+		if !e.FirstResponseAt.IsZero() {
+			ret.Responded++
+			responses = append(responses, e.FirstResponseAt.Sub(e.CreatedAt))
+		}
+		if e.FirstTimeContributor {
+			ret.FirstTimeContributors++
+		}
+	}
+
+	ret.MedianTimeToMerge = percentile(merges, 50)
+	ret.P90TimeToMerge = percentile(merges, 90)
+	ret.MedianTimeToFirstResponse = percentile(responses, 50)
+	ret.OpenAges = ageBuckets(openPulls)
+	return ret
+}
+
+// MergedRatio returns the share of the merged ones in the closed pull requests.
+func (p PullStats) MergedRatio() float64 {
+	closed := p.Merged + p.ClosedUnmerged
+	if closed == 0 {
+		return 0
+	}
+	return float64(p.Merged) / float64(closed)
+}
+
+// FirstTimeContributorShare returns the share of the pull requests from the
+// first-time contributors.
+func (p PullStats) FirstTimeContributorShare() float64 {
+	if p.Count == 0 {
+		return 0
+	}
+	return float64(p.FirstTimeContributors) / float64(p.Count)
+}
+
+// NewIssueStats summarizes issues.
+func NewIssueStats(issues []IssueLifecycle) IssueStats {
+	var (
+		ret       = IssueStats{Count: len(issues)}
+		closes    []time.Duration
+		responses []time.Duration
+	)
+
+	for _, e := range issues {
+		if !e.ClosedAt.IsZero() {
+			ret.Closed++
+			closes = append(closes, e.ClosedAt.Sub(e.CreatedAt))
+		}
+		if e.Stale {
+			ret.Stale++
+		}
+		if !e.FirstResponseAt.IsZero() {
+			ret.Responded++
+			responses = append(responses, e.FirstResponseAt.Sub(e.CreatedAt))
+		}
+	}
+
+	ret.MedianTimeToClose = percentile(closes, 50)
+	ret.MedianTimeToFirstResponse = percentile(responses, 50)
+	return ret
+}
+
+// StaleRatio returns the share of the ones closed as stale in the closed
+// issues.
+func (i IssueStats) StaleRatio() float64 {
+	if i.Closed == 0 {
+		return 0
+	}
+	return float64(i.Stale) / float64(i.Closed)
+}
+
+// ageBuckets distributes the ages of times into a week, a month, a quarter
+// and older.
+func ageBuckets(times []time.Time) []AgeBucket {
+	if times == nil {
+		return nil
+	}
+
+	buckets := []AgeBucket{
+		{Label: "<1w", Max: timeWeek},
+		{Label: "<1m", Max: timeMonth},
+		{Label: "<3m", Max: timeQuarter},
+		{Label: ">=3m"},
+	}
+	now := time.Now()
+	for _, e := range times {
+		age := now.Sub(e)
+		for i, b := range buckets {
+			if b.Max == 0 || age < b.Max {
+				buckets[i].Count++
+				break
+			}
+		}
+	}
+	return buckets
+}
+
+// percentile returns the nearest-rank p-th percentile of list.
+func percentile(list []time.Duration, p int) time.Duration {
+	if len(list) == 0 {
+		return 0
+	}
+
+	sorted := append([]time.Duration(nil), list...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,139 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewPullStats(t *testing.T) {
+	var (
+		now   = time.Now()
+		hours = func(n int) time.Time { return now.Add(time.Duration(n) * time.Hour) }
+	)
+	pulls := []PullLifecycle{
+		{CreatedAt: hours(-100), MergedAt: hours(-98), FirstResponseAt: hours(-99)},
+		{CreatedAt: hours(-100), MergedAt: hours(-90), FirstResponseAt: hours(-97)},
+		{CreatedAt: hours(-100), MergedAt: hours(-60)},
+		{CreatedAt: hours(-100), ClosedAt: hours(-50), FirstTimeContributor: true},
+		{CreatedAt: hours(-10), FirstTimeContributor: true},
+	}
+	openPulls := []time.Time{hours(-1), hours(-24 * 10), hours(-24 * 200), hours(-24 * 300)}
+
+	s := NewPullStats(pulls, openPulls)
+	if s.Count != 5 || s.Merged != 3 || s.ClosedUnmerged != 1 || s.Responded != 2 {
+		t.Fatalf("unexpected counts: %+v", s)
+	}
+	if s.MergedRatio() != 0.75 || s.FirstTimeContributorShare() != 0.4 {
+		t.Fatalf("unexpected ratios: %v, %v", s.MergedRatio(), s.FirstTimeContributorShare())
+	}
+	if s.MedianTimeToMerge != 10*time.Hour || s.P90TimeToMerge != 40*time.Hour ||
+		s.MedianTimeToFirstResponse != time.Hour {
+		t.Fatalf("unexpected durations: %+v", s)
+	}
+
+	var counts []int
+	for _, e := range s.OpenAges {
+		counts = append(counts, e.Count)
+	}
+	if len(counts) != 4 || counts[0] != 1 || counts[1] != 1 || counts[2] != 0 || counts[3] != 2 {
+		t.Fatalf("unexpected open ages: %+v", s.OpenAges)
+	}
+}
+
+func TestNewIssueStats(t *testing.T) {
+	var (
+		now   = time.Now()
+		hours = func(n int) time.Time { return now.Add(time.Duration(n) * time.Hour) }
+	)
+	issues := []IssueLifecycle{
+		{CreatedAt: hours(-100), ClosedAt: hours(-96), FirstResponseAt: hours(-99)},
+		{CreatedAt: hours(-100), ClosedAt: hours(-90), FirstResponseAt: hours(-97)},
+		{CreatedAt: hours(-100), ClosedAt: hours(-10), Stale: true},
+		{CreatedAt: hours(-10), FirstResponseAt: hours(-5)},
+	}
+
+	s := NewIssueStats(issues)
+	if s.Count != 4 || s.Closed != 3 || s.Stale != 1 || s.Responded != 3 {
+		t.Fatalf("unexpected counts: %+v", s)
+	}
+	if s.MedianTimeToClose != 10*time.Hour || s.MedianTimeToFirstResponse != 3*time.Hour {
+		t.Fatalf("unexpected durations: %+v", s)
+	}
+	if ratio := s.StaleRatio(); ratio < 0.33 || ratio > 0.34 {
+		t.Fatalf("unexpected stale ratio: %v", ratio)
+	}
+}
+
+func TestUnknownPulls(t *testing.T) {
+	var pulls PullRequestList
+	since := time.Now().Add(-timeQuarter)
+	if pulls.times(since) != nil || pulls.lifecycles(since) != nil {
+		t.Fatal("expected unknown pull requests")
+	}
+	if l := (PullRequestList{}).lifecycles(since); l == nil {
+		t.Fatal("expected no pull requests")
+	}
+
+	d := NewData(Result{PullLifecycles: pulls.lifecycles(since)}, Windows{})
+	if d.PullMergeRatio != "N/A" || d.OpenPullAge != "N/A" {
+		t.Fatalf("unexpected data: %+v", d)
+	}
+}
+
+func TestTruncatedPulls(t *testing.T) {
+	now := time.Now()
+	list := []PullLifecycle{{CreatedAt: now.Add(-timeDay), MergedAt: now}}
+
+	d := NewData(Result{PullLifecycles: list, PullsTruncated: true}, Windows{})
+	if !strings.HasSuffix(d.PullMergeRatio, "(latest 1 pulls)") ||
+		!strings.HasSuffix(d.PullMergeTime, "(latest 1 pulls)") {
+		t.Fatalf("truncation not shown: %s, %s", d.PullMergeRatio, d.PullMergeTime)
+	}
+}
+
+func TestUnknownIssues(t *testing.T) {
+	var issues IssueList
+	since := time.Now().Add(-timeQuarter)
+	if issues.times(since) != nil || issues.lifecycles(since) != nil {
+		t.Fatal("expected unknown issues")
+	}
+
+	d := NewData(Result{IssueLifecycles: issues.lifecycles(since)}, Windows{})
+	if d.StaleIssueRatio != "N/A" || d.IssueCloseTime != "N/A" {
+		t.Fatalf("unexpected data: %+v", d)
+	}
+}
+
+func TestTruncatedIssues(t *testing.T) {
+	now := time.Now()
+	list := []IssueLifecycle{{CreatedAt: now.Add(-timeDay), ClosedAt: now}}
+
+	d := NewData(Result{IssueLifecycles: list, IssuesTruncated: true}, Windows{})
+	if !strings.HasSuffix(d.IssueCloseTime, "(latest 1 issues)") ||
+		!strings.HasSuffix(d.StaleIssueRatio, "(latest 1 issues)") {
+		t.Fatalf("truncation not shown: %s, %s", d.IssueCloseTime, d.StaleIssueRatio)
+	}
+}
@@ -0,0 +1,253 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalPrefix marks a repository on disk, e.g. local:../mirror/go-zero.
+const LocalPrefix = "local:"
+
+const gitFieldSep = "\x1f"
+
+var licenseFiles = []string{"LICENSE", "LICENSE.md", "LICENSE.txt", "LICENCE", "COPYING", "License"}
+
+type localCommit struct {
+	author     string
+	authoredAt time.Time
+	commitAt   time.Time
+}
+
+func (c localCommit) authored() AuthoredCommit {
+	return AuthoredCommit{
+		Author:       c.author,
+		Organization: organization("", c.author),
+		AuthoredAt:   c.authoredAt,
+	}
+}
+
+// Local analyses a git repository on disk without the GitHub API, only the
+// fields which can be derived from the git history are filled.
+func Local(ctx context.Context, windows Windows, path string) (Result, error) {
+	dir := localDir(path)
+	if _, err := git(ctx, dir, "rev-parse", "--git-dir"); err != nil {
+		return Result{}, fmt.Errorf("%s is not a git repository: %w", dir, err)
+	}
+
+	commits, err := localCommits(ctx, dir)
+	if err != nil {
+		return Result{}, err
+	}
+	tags, err := localTags(ctx, dir)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var (
+		authors  = make(map[string]struct{})
+		deadline = time.Now().Add(-windows.Activity)
+		since    = time.Now().Add(-windows.History)
+		r        = Result{
+			FullName:       path,
+			License:        localLicense(dir),
+			StarCount:      Unknown,
+			ForkCount:      Unknown,
+			WatcherCount:   Unknown,
+			IssueCount:     Unknown,
+			OpenIssueCount: Unknown,
+			PullCount:      Unknown,
+			OpenPullCount:  Unknown,
+			ReleaseCount:   len(tags),
+			Commits:        []time.Time{},
+			History:        []AuthoredCommit{},
+			Releases:       tags,
+		}
+	)
+	for _, e := range commits {
+		authors[e.author] = struct{}{}
+		if r.CreatedAt.IsZero() || e.authoredAt.Before(r.CreatedAt) {
+			r.CreatedAt = e.authoredAt
+		}
+		if e.commitAt.After(r.PushedAt) {
+			r.PushedAt = e.commitAt
+		}
+		if e.authoredAt.After(deadline) {
+			r.Commits = append(r.Commits, e.authoredAt)
+		}
+		if e.authoredAt.After(since) {
+			r.History = append(r.History, e.authored())
+		}
+	}
+	for _, e := range tags {
+		if e.PublishedAt.After(r.LatestReleaseAt) {
+			r.LatestReleaseAt = e.PublishedAt
+		}
+	}
+
+	homepage, _ := git(ctx, dir, "config", "--get", "remote.origin.url")
+	r.Homepage = strings.TrimSpace(homepage)
+	r.UpdatedAt = r.PushedAt
+	r.ContributorCount = len(authors)
+	return r, nil
+}
+
+// localPathPrefixes mark a path on disk, a bare owner/repo is always a remote
+// repository even if such a directory exists.
+var localPathPrefixes = []string{LocalPrefix, "./", "../", "/", "~"}
+
+// IsLocal reports whether repo refers to a repository on disk.
+func IsLocal(repo string) bool {
+	if repo == "." || repo == ".." {
+		return true
+	}
+	for _, e := range localPathPrefixes {
+		if strings.HasPrefix(repo, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// localDir turns a local repository into its directory, ~ is the home
+// directory.
+func localDir(repo string) string {
+	dir := strings.TrimPrefix(repo, LocalPrefix)
+	if dir != "~" && !strings.HasPrefix(dir, "~/") {
+		return dir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return dir
+	}
+	return filepath.Join(home, strings.TrimPrefix(dir, "~"))
+}
+
+func localCommits(ctx context.Context, dir string) ([]localCommit, error) {
+	out, err := git(ctx, dir, "log", "--use-mailmap",
+		"--format=%aE"+gitFieldSep+"%aI"+gitFieldSep+"%cI")
+	if err != nil {
+		// an empty repository has no HEAD
+		if _, headErr := git(ctx, dir, "rev-parse", "--verify", "HEAD"); headErr != nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var (
+		list    []localCommit
+		scanner = bufio.NewScanner(strings.NewReader(out))
+	)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), gitFieldSep)
+		if len(fields) != 3 {
+			continue
+		}
+
+		authoredAt, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			return nil, err
+		}
+		commitAt, err := time.Parse(time.RFC3339, fields[2])
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, localCommit{
+			author:     strings.ToLower(fields[0]),
+			authoredAt: authoredAt,
+			commitAt:   commitAt,
+		})
+	}
+
+	return list, scanner.Err()
+}
+
+func localTags(ctx context.Context, dir string) ([]PublishedRelease, error) {
+	out, err := git(ctx, dir, "for-each-ref",
+		"--format=%(refname:short)"+gitFieldSep+"%(creatordate:iso-strict)", "refs/tags")
+	if err != nil {
+		return nil, err
+	}
+
+	list := []PublishedRelease{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Split(line, gitFieldSep)
+		if len(fields) != 2 {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, PublishedRelease{Tag: fields[0], PublishedAt: t})
+	}
+
+	return list, nil
+}
+
+// localLicense returns the first line of the license file as the license name.
+func localLicense(dir string) string {
+	for _, e := range licenseFiles {
+		data, err := ioutil.ReadFile(filepath.Join(dir, e))
+		if err != nil {
+			continue
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			line := strings.Trim(strings.TrimSpace(scanner.Text()), "#= ")
+			if len(line) > 0 {
+				return line
+			}
+		}
+	}
+
+	return ""
+}
+
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if stderr.Len() > 0 {
+			return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return "", err
+	}
+
+	return string(out), nil
+}
@@ -0,0 +1,107 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocal(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE=2020-01-01T00:00:00Z")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v %s", args, err, out)
+		}
+	}
+
+	run("init", "-q")
+	if err := ioutil.WriteFile(filepath.Join(dir, "LICENSE"), []byte("MIT License\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "LICENSE")
+	run("-c", "user.name=a", "-c", "user.email=a@example.com", "commit", "-q", "-m", "init")
+	run("-c", "user.name=b", "-c", "user.email=b@example.com", "commit", "-q", "--allow-empty",
+		"-m", "second")
+	run("tag", "v1.0.0")
+
+	r, err := Local(context.Background(), DefaultWindows(), LocalPrefix+dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := NewData(r, DefaultWindows())
+	if data.Metrics.ContributorCount != 2 || data.Metrics.ReleaseCount != 1 ||
+		!data.Metrics.HasLicense || data.License != "MIT License" {
+		t.Fatalf("unexpected data: %+v", data)
+	}
+	if data.Metrics.CreatedAt.Year() != 2020 || data.Metrics.AgeDays == 0 {
+		t.Fatalf("unexpected age: %+v", data.Metrics)
+	}
+
+	if _, err := Local(context.Background(), DefaultWindows(), t.TempDir()); err == nil {
+		t.Fatal("expected error for non-git directory")
+	}
+}
+
+func TestIsLocal(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "spf13", "cobra"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.Chdir(wd)
+	}()
+
+	// the directory exists but a bare owner/repo is on GitHub
+	if IsLocal("spf13/cobra") || IsExternal("spf13/cobra") {
+		t.Fatal("expected spf13/cobra to be remote")
+	}
+	for _, e := range []string{"./spf13/cobra", "../cobra", dir, "~/src/cobra", ".",
+		LocalPrefix + "spf13/cobra"} {
+		if !IsLocal(e) {
+			t.Fatalf("expected %s to be local", e)
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err == nil && localDir("~/src/cobra") != filepath.Join(home, "src", "cobra") {
+		t.Fatalf("unexpected dir: %s", localDir("~/src/cobra"))
+	}
+}
@@ -0,0 +1,125 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"math"
+	"time"
+)
+
+// Metrics are the raw values of Data, the counts of the source are Unknown if
+// it doesn't provide them.
+type Metrics struct {
+	StarCount             int       `json:"starCount"`
+	ForkCount             int       `json:"forkCount"`
+	WatcherCount          int       `json:"watcherCount"`
+	OpenIssueCount        int       `json:"openIssueCount"`
+	IssueCount            int       `json:"issueCount"`
+	OpenPullCount         int       `json:"openPullCount"`
+	PullCount             int       `json:"pullCount"`
+	ContributorCount      int       `json:"contributorCount"`
+	ReleaseCount          int       `json:"releaseCount"`
+	LatestDayStarCount    int       `json:"latestDayStarCount"`
+	LatestWeekStarCount   int       `json:"latestWeekStarCount"`
+	LatestMonthStarCount  int       `json:"latestMonthStarCount"`
+	LatestWeekCommitCount int       `json:"latestWeekCommitCount"`
+	LatestWeekPullCount   int       `json:"latestWeekPullCount"`
+	AgeDays               int       `json:"ageDays"`
+	AvgReleasePeriodDays  float64   `json:"avgReleasePeriodDays"`
+	HasLicense            bool      `json:"hasLicense"`
+	CreatedAt             time.Time `json:"createdAt"`
+	PushedAt              time.Time `json:"pushedAt"`
+	UpdatedAt             time.Time `json:"updatedAt"`
+	LatestReleaseAt       time.Time `json:"latestReleaseAt"`
+
+	MergedPullRatio         float64 `json:"mergedPullRatio"`
+	MergedPullCount         int     `json:"mergedPullCount"`
+	MedianPullMergeHours    float64 `json:"medianPullMergeHours"`
+	P90PullMergeHours       float64 `json:"p90PullMergeHours"`
+	RespondedPullCount      int     `json:"respondedPullCount"`
+	MedianPullResponseHours float64 `json:"medianPullResponseHours"`
+	FirstTimePullShare      float64 `json:"firstTimePullShare"`
+
+	RespondedIssueCount      int     `json:"respondedIssueCount"`
+	MedianIssueResponseHours float64 `json:"medianIssueResponseHours"`
+	ClosedIssueCount         int     `json:"closedIssueCount"`
+	MedianIssueCloseHours    float64 `json:"medianIssueCloseHours"`
+	StaleIssueRatio          float64 `json:"staleIssueRatio"`
+	IssueBacklogGrowth       int     `json:"issueBacklogGrowth"`
+
+	HistoryCommitCount    int     `json:"historyCommitCount"`
+	TopContributorShare   float64 `json:"topContributorShare"`
+	Top3ContributorShare  float64 `json:"top3ContributorShare"`
+	Top5ContributorShare  float64 `json:"top5ContributorShare"`
+	BusFactor             int     `json:"busFactor"`
+	ActiveMaintainerCount int     `json:"activeMaintainerCount"`
+	OrganizationCount     int     `json:"organizationCount"`
+	TopOrganizationShare  float64 `json:"topOrganizationShare"`
+
+	StableReleaseCount        int       `json:"stableReleaseCount"`
+	MedianReleaseIntervalDays float64   `json:"medianReleaseIntervalDays"`
+	ReleaseIntervalTrend      float64   `json:"releaseIntervalTrend"`
+	LatestStableReleaseAt     time.Time `json:"latestStableReleaseAt"`
+	ReleaseDownloads          int       `json:"releaseDownloads"`
+
+	StargazerAccountCount int     `json:"stargazerAccountCount"`
+	LowSignalStarShare    float64 `json:"lowSignalStarShare"`
+	StarBurstDays         int     `json:"starBurstDays"`
+
+	Archived        bool `json:"archived"`
+	CommunityHealth int  `json:"communityHealth"`
+
+	ProjectedStarCount int `json:"projectedStarCount"`
+	ProjectedForkCount int `json:"projectedForkCount"`
+	AnomalyCount       int `json:"anomalyCount"`
+}
+
+// StarsPerDay returns the average stars per day, it's NaN if the stars are
+// unknown.
+func (m Metrics) StarsPerDay() float64 {
+	if m.StarCount < 0 {
+		return math.NaN()
+	}
+	if m.AgeDays < 1 {
+		return float64(m.StarCount)
+	}
+	return float64(m.StarCount) / float64(m.AgeDays)
+}
+
+// OpenIssueRatio returns the share of the open issues, it's NaN if the issues
+// are unknown or there isn't any.
+func (m Metrics) OpenIssueRatio() float64 {
+	if m.IssueCount <= 0 || m.OpenIssueCount < 0 {
+		return math.NaN()
+	}
+	return float64(m.OpenIssueCount) / float64(m.IssueCount)
+}
+
+// DaysSincePush returns the days since the latest push, it's NaN if the push
+// time is unknown.
+func (m Metrics) DaysSincePush() float64 {
+	if m.PushedAt.IsZero() {
+		return math.NaN()
+	}
+	return time.Since(m.PushedAt).Hours() / 24
+}
@@ -0,0 +1,100 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import "context"
+
+const (
+	StageMetadata     Stage = "metadata"
+	StageContributors Stage = "contributors"
+	StageStargazers   Stage = "stargazers"
+	StageForks        Stage = "forks"
+	StageCommits      Stage = "commits"
+	StagePulls        Stage = "pulls"
+	StageOpenPulls    Stage = "open pulls"
+	StageIssues       Stage = "issues"
+	StageHistory      Stage = "history"
+	StageReleases     Stage = "releases"
+	StageCommunity    Stage = "community"
+)
+
+type (
+	// Stage is a step of fetching the statistics of a repository.
+	Stage string
+
+	// Event reports the progress of a stage, Page is the 1-based page which is
+	// being requested, Done is true once the stage is finished with or without
+	// an error.
+	Event struct {
+		Repo  string
+		Stage Stage
+		Page  int
+		Done  bool
+		Err   error
+	}
+
+	// ProgressFunc receives the events of fetching, it's called from multiple
+	// goroutines.
+	ProgressFunc func(Event)
+
+	progressKey struct{}
+)
+
+// Stages lists the stages in the order of presentation.
+var Stages = []Stage{StageMetadata, StageContributors, StageStargazers, StageForks,
+	StageCommits, StagePulls, StageOpenPulls, StageIssues, StageHistory, StageReleases,
+	StageCommunity}
+
+// WithProgress returns a context which reports the progress of Fetch and
+// FetchExternal to fn.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+func report(ctx context.Context, e Event) {
+	if fn, ok := ctx.Value(progressKey{}).(ProgressFunc); ok && fn != nil {
+		fn(e)
+	}
+}
+
+// reportPage reports a page of stage is being requested.
+func reportPage(ctx context.Context, repo string, stage Stage, page int) {
+	report(ctx, Event{Repo: repo, Stage: stage, Page: page})
+}
+
+// reportDone reports stage is finished, err is nil on success.
+func reportDone(ctx context.Context, repo string, stage Stage, err error) {
+	report(ctx, Event{Repo: repo, Stage: stage, Done: true, Err: err})
+}
+
+func (s Stat) fullName() string {
+	return s.owner + "/" + s.repo
+}
+
+func (s Stat) reportPage(stage Stage, page int) {
+	reportPage(s.ctx, s.fullName(), stage, page)
+}
+
+func (s Stat) reportDone(stage Stage, err error) {
+	reportDone(s.ctx, s.fullName(), stage, err)
+}
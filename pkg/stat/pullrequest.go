@@ -23,10 +23,8 @@
 package stat
 
 import (
-	"log"
 	"time"
 
-	"github.com/anqiansong/github-compare/pkg/timex"
 	"github.com/shurcooL/githubv4"
 )
 
@@ -34,7 +32,13 @@ type (
 	PullRequestList []PullRequestEdge
 
 	PullRequestNode struct {
-		CreatedAt githubv4.DateTime
+		CreatedAt         githubv4.DateTime
+		MergedAt          githubv4.DateTime
+		ClosedAt          githubv4.DateTime
+		AuthorAssociation githubv4.String
+		Author            Actor
+		Reviews           ResponseConnection `graphql:"reviews(first: 5)"`
+		Comments          ResponseConnection `graphql:"comments(first: 5)"`
 	}
 
 	PullRequestEdge struct {
@@ -55,64 +59,80 @@ type (
 	PRQuery struct {
 		PullRequest PullRequest `graphql:"repository(owner: $owner, name: $name)"`
 	}
-)
 
-func (s Stat) OpenPullRequestCount() githubv4.Int {
-	var prQuery PRQuery
-	_ = s.graphqlClient.Query(s.ctx, &prQuery, map[string]interface{}{
-		"after":             (*githubv4.String)(nil),
-		"owner":             githubv4.String(s.owner),
-		"name":              githubv4.String(s.repo),
-		"first":             githubv4.Int(1),
-		"pullRequestStates": []githubv4.PullRequestState{githubv4.PullRequestStateOpen},
-		"orderBy": githubv4.IssueOrder{
-			Field:     githubv4.IssueOrderFieldCreatedAt,
-			Direction: githubv4.OrderDirectionDesc,
-		},
-	})
+	OpenPullRequestNode struct {
+		CreatedAt githubv4.DateTime
+	}
 
-	return prQuery.PullRequest.List.TotalCount
-}
+	OpenPullRequestConnection struct {
+		Nodes    []OpenPullRequestNode
+		PageInfo PageInfo
+	}
 
-func (p PullRequestList) Chart() Chart {
-	var (
-		labels  []string
-		data    []float64
-		now     = time.Now()
-		dayTime = timex.AllDays(now.Add(-weekDur), now)
-	)
+	OpenPullRequestQuery struct {
+		Repository struct {
+			PullRequests OpenPullRequestConnection `graphql:"pullRequests(first: 100, after: $after, states: OPEN, orderBy: $orderBy)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+)
+
+const (
+	// maxPullPages limits the pull requests within the windows to the newest
+	// 1000.
+	maxPullPages = 10
+	// maxOpenPullPages limits the open pull requests to the newest 1000.
+	maxOpenPullPages = 10
+)
 
-	for _, t := range dayTime {
-		label := t.Format(labelLayout)
-		labels = append(labels, label)
-		data = append(data, float64(p.getSpecifiedDate(t)))
+// times returns the creation times of the pull requests since since, it's nil
+// if the pull requests are unknown.
+func (p PullRequestList) times(since time.Time) timeList {
+	if p == nil {
+		return nil
 	}
 
-	return Chart{Data: data, Labels: labels}
+	list := make(timeList, 0, len(p))
+	for _, e := range p {
+		if e.Node.CreatedAt.Time.After(since) {
+			list = append(list, e.Node.CreatedAt.Time)
+		}
+	}
+	return list
 }
 
-func (p PullRequestList) getSpecifiedDate(date time.Time) int {
-	var (
-		count int
-		zero  = timex.Truncate(date)
-	)
+// lifecycles returns the lifecycles of the pull requests created since since,
+// it's nil if the pull requests are unknown.
+func (p PullRequestList) lifecycles(since time.Time) []PullLifecycle {
+	if p == nil {
+		return nil
+	}
 
+	list := make([]PullLifecycle, 0, len(p))
 	for _, e := range p {
-		if timex.Truncate(e.Node.CreatedAt.Time).Equal(zero) {
-			count += 1
+		n := e.Node
+		if !n.CreatedAt.Time.After(since) {
+			continue
 		}
+		list = append(list, PullLifecycle{
+			CreatedAt:            n.CreatedAt.Time,
+			MergedAt:             n.MergedAt.Time,
+			ClosedAt:             n.ClosedAt.Time,
+			FirstResponseAt:      firstResponse(n.Author, n.Reviews, n.Comments),
+			FirstTimeContributor: isFirstTimer(n.AuthorAssociation),
+		})
 	}
-
-	return count
+	return list
 }
 
-func (s Stat) latestWeekPRS() PullRequestList {
+// pulls fetches at most maxPullPages of the pull requests created within the
+// longest window, it's nil if the query fails. truncated tells whether there
+// are more pull requests within the window.
+func (s Stat) pulls() (list PullRequestList, truncated bool) {
 	var (
 		brk      bool
 		prQuery  PRQuery
-		list     PullRequestList
 		after    githubv4.String
-		deadline = time.Now().Add(-timeWeek)
+		deadline = time.Now().Add(-s.windows.longest())
 	)
 
 	arg := map[string]interface{}{
@@ -128,13 +148,17 @@ func (s Stat) latestWeekPRS() PullRequestList {
 		},
 	}
 
-	for {
-		err := s.graphqlClient.Query(s.ctx, &prQuery, arg)
-		if err != nil {
-			log.Fatalln(err)
+	for page := 1; page <= maxPullPages; page++ {
+		s.reportPage(StagePulls, page)
+		if err := s.graphqlClient.Query(s.ctx, &prQuery, arg); err != nil {
+			s.reportDone(StagePulls, err)
+			return nil, false
 		}
 
 		temp := prQuery.PullRequest.List.Edges
+		if list == nil {
+			list = make(PullRequestList, 0, len(temp))
+		}
 		for _, e := range temp {
 			if e.Node.CreatedAt.Time.Before(deadline) {
 				brk = true
@@ -146,10 +170,51 @@ func (s Stat) latestWeekPRS() PullRequestList {
 		if brk || !(bool)(prQuery.PullRequest.List.PageInfo.HasNextPage) || len(temp) == 0 {
 			break
 		}
+		truncated = page == maxPullPages
 
 		after = temp[len(temp)-1].Cursor
 		arg["after"] = after
 	}
 
+	s.reportDone(StagePulls, nil)
+	return list, truncated
+}
+
+// openPulls fetches the creation times of the newest open pull requests, it's
+// nil if the query fails.
+func (s Stat) openPulls() timeList {
+	var (
+		query OpenPullRequestQuery
+		list  = timeList{}
+	)
+
+	arg := map[string]interface{}{
+		"after": (*githubv4.String)(nil),
+		"owner": githubv4.String(s.owner),
+		"name":  githubv4.String(s.repo),
+		"orderBy": githubv4.IssueOrder{
+			Field:     githubv4.IssueOrderFieldCreatedAt,
+			Direction: githubv4.OrderDirectionDesc,
+		},
+	}
+
+	for page := 1; page <= maxOpenPullPages; page++ {
+		s.reportPage(StageOpenPulls, page)
+		if err := s.graphqlClient.Query(s.ctx, &query, arg); err != nil {
+			s.reportDone(StageOpenPulls, err)
+			return nil
+		}
+
+		conn := query.Repository.PullRequests
+		for _, e := range conn.Nodes {
+			list = append(list, e.CreatedAt.Time)
+		}
+		if !(bool)(conn.PageInfo.HasNextPage) {
+			break
+		}
+		arg["after"] = githubv4.NewString(conn.PageInfo.EndCursor)
+	}
+
+	s.reportDone(StageOpenPulls, nil)
 	return list
 }
@@ -0,0 +1,110 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"sort"
+	"time"
+)
+
+// PunchCard counts the commits by weekday and hour in the time zones of the
+// authors.
+type PunchCard struct {
+	// Matrix is indexed by time.Weekday and hour.
+	Matrix    [7][24]int      `json:"matrix"`
+	TimeZones []TimeZoneCount `json:"timeZones"`
+}
+
+// TimeZoneCount is the number of the commits authored in a time zone, Offset
+// is like +08:00.
+type TimeZoneCount struct {
+	Offset string `json:"offset"`
+	Count  int    `json:"count"`
+}
+
+// NewPunchCard builds the punch card of history.
+func NewPunchCard(history []AuthoredCommit) PunchCard {
+	var (
+		ret   PunchCard
+		zones = make(map[string]int)
+	)
+	for _, e := range history {
+		ret.Matrix[e.AuthoredAt.Weekday()][e.AuthoredAt.Hour()]++
+		zones[e.AuthoredAt.Format("-07:00")]++
+	}
+
+	for offset, count := range zones {
+		ret.TimeZones = append(ret.TimeZones, TimeZoneCount{Offset: offset, Count: count})
+	}
+	sort.Slice(ret.TimeZones, func(i, j int) bool {
+		a, b := ret.TimeZones[i], ret.TimeZones[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Offset < b.Offset
+	})
+	return ret
+}
+
+// Total returns the number of the commits.
+func (p PunchCard) Total() int {
+	var total int
+	for _, row := range p.Matrix {
+		for _, e := range row {
+			total += e
+		}
+	}
+	return total
+}
+
+// WeekendShare returns the share of the commits on Saturday and Sunday.
+func (p PunchCard) WeekendShare() float64 {
+	return p.share(func(day time.Weekday, _ int) bool {
+		return day == time.Saturday || day == time.Sunday
+	})
+}
+
+// OfficeHourShare returns the share of the commits from 9 to 18 o'clock on
+// weekdays.
+func (p PunchCard) OfficeHourShare() float64 {
+	return p.share(func(day time.Weekday, hour int) bool {
+		return day != time.Saturday && day != time.Sunday && hour >= 9 && hour < 18
+	})
+}
+
+func (p PunchCard) share(match func(day time.Weekday, hour int) bool) float64 {
+	total := p.Total()
+	if total == 0 {
+		return 0
+	}
+
+	var count int
+	for day, row := range p.Matrix {
+		for hour, e := range row {
+			if match(time.Weekday(day), hour) {
+				count += e
+			}
+		}
+	}
+	return float64(count) / float64(total)
+}
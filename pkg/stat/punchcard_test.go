@@ -0,0 +1,54 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewPunchCard(t *testing.T) {
+	var (
+		shanghai = time.FixedZone("", 8*3600)
+		pacific  = time.FixedZone("", -7*3600)
+	)
+	history := []AuthoredCommit{
+		// Monday 10:00 in +08:00, it's Sunday in UTC
+		{AuthoredAt: time.Date(2022, 6, 6, 2, 0, 0, 0, time.UTC).In(shanghai)},
+		{AuthoredAt: time.Date(2022, 6, 6, 11, 0, 0, 0, shanghai)},
+		{AuthoredAt: time.Date(2022, 6, 4, 22, 0, 0, 0, pacific)},
+		{AuthoredAt: time.Date(2022, 6, 5, 23, 0, 0, 0, shanghai)},
+	}
+
+	p := NewPunchCard(history)
+	if p.Matrix[time.Monday][10] != 1 || p.Matrix[time.Monday][11] != 1 ||
+		p.Matrix[time.Saturday][22] != 1 || p.Matrix[time.Sunday][23] != 1 {
+		t.Fatalf("unexpected matrix: %v", p.Matrix)
+	}
+	if p.Total() != 4 || p.WeekendShare() != 0.5 || p.OfficeHourShare() != 0.5 {
+		t.Fatalf("unexpected shares: %v, %v", p.WeekendShare(), p.OfficeHourShare())
+	}
+	if len(p.TimeZones) != 2 || p.TimeZones[0] != (TimeZoneCount{Offset: "+08:00", Count: 3}) {
+		t.Fatalf("unexpected time zones: %+v", p.TimeZones)
+	}
+}
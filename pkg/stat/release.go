@@ -24,6 +24,14 @@ package stat
 
 import "github.com/shurcooL/githubv4"
 
+const (
+	// maxReleasePages limits the release list to the latest 500 releases.
+	maxReleasePages = 5
+	// maxReleaseAssets limits the assets counted for the downloads of a
+	// release.
+	maxReleaseAssets = 50
+)
+
 type (
 	Release struct {
 		CreatedAt   githubv4.DateTime
@@ -33,4 +41,98 @@ type (
 	ReleaseConnection struct {
 		TotalCount githubv4.Int
 	}
+
+	ReleaseAsset struct {
+		DownloadCount githubv4.Int
+	}
+
+	ReleaseNode struct {
+		TagName       githubv4.String
+		IsPrerelease  githubv4.Boolean
+		IsDraft       githubv4.Boolean
+		CreatedAt     githubv4.DateTime
+		PublishedAt   githubv4.DateTime
+		ReleaseAssets struct {
+			Nodes    []ReleaseAsset
+			PageInfo struct {
+				HasNextPage githubv4.Boolean
+			}
+		} `graphql:"releaseAssets(first: 50)"`
+	}
+
+	ReleaseListConnection struct {
+		Nodes    []ReleaseNode
+		PageInfo PageInfo
+	}
+
+	ReleaseListQuery struct {
+		Repository struct {
+			Releases ReleaseListConnection `graphql:"releases(first: 100, after: $after, orderBy: $orderBy)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
 )
+
+func (r ReleaseNode) published() PublishedRelease {
+	ret := PublishedRelease{
+		Tag:         string(r.TagName),
+		Prerelease:  bool(r.IsPrerelease),
+		PublishedAt: r.PublishedAt.Time,
+	}
+	if ret.PublishedAt.IsZero() {
+		ret.PublishedAt = r.CreatedAt.Time
+	}
+	for _, e := range r.ReleaseAssets.Nodes {
+		ret.Downloads += int(e.DownloadCount)
+	}
+	return ret
+}
+
+// releases fetches the latest releases except the drafts, it's nil if the
+// query fails. truncated tells whether there are more releases than
+// maxReleasePages or more assets of a release than maxReleaseAssets.
+func (s Stat) releases() (list []PublishedRelease, truncated bool) {
+	var query ReleaseListQuery
+
+	arg := map[string]interface{}{
+		"after": (*githubv4.String)(nil),
+		"owner": githubv4.String(s.owner),
+		"name":  githubv4.String(s.repo),
+		"orderBy": githubv4.ReleaseOrder{
+			Field:     githubv4.ReleaseOrderFieldCreatedAt,
+			Direction: githubv4.OrderDirectionDesc,
+		},
+	}
+
+	for page := 1; page <= maxReleasePages; page++ {
+		s.reportPage(StageReleases, page)
+		if err := s.graphqlClient.Query(s.ctx, &query, arg); err != nil {
+			s.reportDone(StageReleases, err)
+			return nil, false
+		}
+
+		conn := query.Repository.Releases
+		if list == nil {
+			list = make([]PublishedRelease, 0, len(conn.Nodes))
+		}
+		for _, e := range conn.Nodes {
+			if e.IsDraft {
+				continue
+			}
+			list = append(list, e.published())
+			if e.ReleaseAssets.PageInfo.HasNextPage {
+				truncated = true
+			}
+		}
+		if !(bool)(conn.PageInfo.HasNextPage) {
+			break
+		}
+		if page == maxReleasePages {
+			truncated = true
+		}
+
+		arg["after"] = githubv4.NewString(conn.PageInfo.EndCursor)
+	}
+
+	s.reportDone(StageReleases, nil)
+	return list, truncated
+}
@@ -47,16 +47,23 @@ type (
 		Nodes []RepositoryTopic
 	}
 
+	CountConnection struct {
+		TotalCount githubv4.Int
+	}
+
 	Repository struct {
 		CreatedAt        githubv4.DateTime
 		ForkCount        githubv4.Int
 		HomepageUrl      githubv4.URI
-		Issues           IssueConnection `graphql:"issues(first: 1, states: $issueStates)"`
+		Issues           CountConnection `graphql:"issues(states: $issueStates)"`
+		OpenIssues       CountConnection `graphql:"openIssues: issues(states: OPEN)"`
 		LatestRelease    Release
 		LicenseInfo      License
 		PrimaryLanguage  Language
+		Languages        LanguageConnection `graphql:"languages(first: 10, orderBy: {field: SIZE, direction: DESC})"`
 		NameWithOwner    githubv4.String
-		PullRequests     PullRequestConnection `graphql:"pullRequests(first: 1, states: $pullRequestStates)"`
+		PullRequests     CountConnection `graphql:"pullRequests(states: $pullRequestStates)"`
+		OpenPullRequests CountConnection `graphql:"openPullRequests: pullRequests(states: OPEN)"`
 		PushedAt         githubv4.DateTime
 		Releases         ReleaseConnection `graphql:"releases(first: 1, orderBy: $orderBy)"`
 		StargazerCount   githubv4.Int
@@ -64,10 +71,18 @@ type (
 		Watchers         UserConnection `graphql:"watchers(first: 1)"`
 		Description      githubv4.String
 		RepositoryTopics RepositoryTopicConnection `graphql:"repositoryTopics(first: 100)"`
-	}
 
-	RepositoryQuery struct {
-		Repository Repository `graphql:"repository(owner: $owner, name: $name)"`
+		IsArchived              githubv4.Boolean
+		IsFork                  githubv4.Boolean
+		IsTemplate              githubv4.Boolean
+		HasDiscussionsEnabled   githubv4.Boolean
+		IsSecurityPolicyEnabled githubv4.Boolean
+		FundingLinks            []FundingLink
+		CodeOfConduct           *CodeOfConduct
+		ContributingGuidelines  *ContributingGuidelines
+		IssueTemplates          []IssueTemplate
+		PullRequestTemplates    []PullRequestTemplate
+		Codeowners              *Codeowners
 	}
 )
 
@@ -79,11 +94,9 @@ func (t RepositoryTopicConnection) List() []string {
 	return list
 }
 
-func (s Stat) Repository() Repository {
-	var repositoryQuery RepositoryQuery
-	_ = s.graphqlClient.Query(s.ctx, &repositoryQuery, map[string]interface{}{
-		"owner": githubv4.String(s.owner),
-		"name":  githubv4.String(s.repo),
+// repositoryVariables returns the variables of Repository except owner and name.
+func repositoryVariables() map[string]interface{} {
+	return map[string]interface{}{
 		"orderBy": githubv4.ReleaseOrder{
 			Field:     githubv4.ReleaseOrderFieldCreatedAt,
 			Direction: githubv4.OrderDirectionDesc,
@@ -92,7 +105,5 @@ func (s Stat) Repository() Repository {
 			githubv4.IssueStateClosed},
 		"pullRequestStates": []githubv4.PullRequestState{githubv4.PullRequestStateOpen,
 			githubv4.PullRequestStateClosed, githubv4.PullRequestStateMerged},
-	})
-
-	return repositoryQuery.Repository
+	}
 }
@@ -0,0 +1,82 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"time"
+
+	"github.com/shurcooL/githubv4"
+)
+
+type (
+	Actor struct {
+		Login githubv4.String
+	}
+
+	Response struct {
+		CreatedAt         githubv4.DateTime
+		Author            Actor
+		AuthorAssociation githubv4.String
+	}
+
+	ResponseConnection struct {
+		Nodes []Response
+	}
+)
+
+// firstResponse returns the time of the first review or comment which is not
+// written by the author.
+func firstResponse(author Actor, list ...ResponseConnection) time.Time {
+	return earliestResponse(author, func(Response) bool { return true }, list...)
+}
+
+// firstMaintainerResponse is like firstResponse but only counts the ones
+// written by the owners, members and collaborators.
+func firstMaintainerResponse(author Actor, list ...ResponseConnection) time.Time {
+	return earliestResponse(author, func(r Response) bool {
+		return isMaintainer(r.AuthorAssociation)
+	}, list...)
+}
+
+func earliestResponse(author Actor, accept func(Response) bool, list ...ResponseConnection) time.Time {
+	var ret time.Time
+	for _, conn := range list {
+		for _, e := range conn.Nodes {
+			if e.Author.Login == author.Login || !accept(e) {
+				continue
+			}
+			if ret.IsZero() || e.CreatedAt.Time.Before(ret) {
+				ret = e.CreatedAt.Time
+			}
+		}
+	}
+	return ret
+}
+
+func isFirstTimer(association githubv4.String) bool {
+	return association == "FIRST_TIMER" || association == "FIRST_TIME_CONTRIBUTOR"
+}
+
+func isMaintainer(association githubv4.String) bool {
+	return association == "OWNER" || association == "MEMBER" || association == "COLLABORATOR"
+}
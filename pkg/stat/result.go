@@ -0,0 +1,245 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"net/http"
+	"os"
+	"time"
+)
+
+// Unknown is the count which the source of a repository doesn't provide.
+const Unknown = -1
+
+type (
+	// Result is the raw statistics of a repository, it's free of formatting.
+	Result struct {
+		FullName        string    `json:"fullName"`
+		Description     string    `json:"description,omitempty"`
+		Homepage        string    `json:"homepage,omitempty"`
+		Language        string    `json:"language,omitempty"`
+		LanguageColor   string    `json:"languageColor,omitempty"`
+		License         string    `json:"license,omitempty"`
+		Topics          []string  `json:"topics,omitempty"`
+		CreatedAt       time.Time `json:"createdAt"`
+		PushedAt        time.Time `json:"pushedAt"`
+		UpdatedAt       time.Time `json:"updatedAt"`
+		LatestReleaseAt time.Time `json:"latestReleaseAt"`
+		// Languages are the top 10 languages by size, it's nil if they are
+		// unknown.
+		Languages []LanguageShare `json:"languages,omitempty"`
+
+		StarCount        int `json:"starCount"`
+		ForkCount        int `json:"forkCount"`
+		WatcherCount     int `json:"watcherCount"`
+		IssueCount       int `json:"issueCount"`
+		OpenIssueCount   int `json:"openIssueCount"`
+		PullCount        int `json:"pullCount"`
+		OpenPullCount    int `json:"openPullCount"`
+		ReleaseCount     int `json:"releaseCount"`
+		ContributorCount int `json:"contributorCount"`
+
+		// Stargazers are the times of the stars within Windows.Stars, at most
+		// the latest 5000 ones of GitHub, it's nil if they are unknown or the
+		// source doesn't provide them. StargazersTruncated tells whether there
+		// are more stars within the window.
+		Stargazers          []time.Time `json:"stargazers,omitempty"`
+		StargazersTruncated bool        `json:"stargazersTruncated,omitempty"`
+		// Forks, Commits, Pulls and Issues are the creation times within
+		// Windows.Activity, Forks and Issues of GitHub are fetched in detail
+		// only.
+		Forks   []time.Time `json:"forks,omitempty"`
+		Commits []time.Time `json:"commits,omitempty"`
+		Pulls   []time.Time `json:"pulls,omitempty"`
+		Issues  []time.Time `json:"issues,omitempty"`
+
+		// PullLifecycles are the pull requests created within
+		// Windows.Lifecycle, at most the newest 1000 ones of GitHub,
+		// OpenPulls are the creation times of the newest 1000 open pull
+		// requests, both are nil if they are unknown. PullsTruncated tells
+		// whether there are more pull requests within the window.
+		PullLifecycles []PullLifecycle `json:"pullLifecycles,omitempty"`
+		OpenPulls      []time.Time     `json:"openPulls,omitempty"`
+		PullsTruncated bool            `json:"pullsTruncated,omitempty"`
+
+		// IssueLifecycles are the issues created within Windows.Lifecycle,
+		// at most the newest 1000 ones of GitHub, it's nil if they are
+		// unknown. IssuesTruncated tells whether there are more issues within
+		// the window. IssuesOpened and IssuesClosed are the numbers of the
+		// issues opened and closed within the window.
+		IssueLifecycles []IssueLifecycle `json:"issueLifecycles,omitempty"`
+		IssuesTruncated bool             `json:"issuesTruncated,omitempty"`
+		IssuesOpened    int              `json:"issuesOpened"`
+		IssuesClosed    int              `json:"issuesClosed"`
+
+		// History is the commits within Windows.History, at most the latest
+		// 1000 ones of GitHub, it's nil if it's unknown. HistoryTruncated
+		// tells whether there are more commits within the window.
+		History          []AuthoredCommit `json:"history,omitempty"`
+		HistoryTruncated bool             `json:"historyTruncated,omitempty"`
+		// Releases are the latest releases except the drafts, at most 500 ones
+		// of GitHub with the downloads of at most 50 assets each, it's nil if
+		// they are unknown. ReleasesTruncated tells whether there are more
+		// releases or assets.
+		Releases          []PublishedRelease `json:"releases,omitempty"`
+		ReleasesTruncated bool               `json:"releasesTruncated,omitempty"`
+		// StargazerAccounts are the profiles of Stargazers, it's nil if they
+		// are unknown.
+		StargazerAccounts []StargazerAccount `json:"stargazerAccounts,omitempty"`
+		// Community is nil if it's unknown.
+		Community *Community `json:"community,omitempty"`
+	}
+
+	// StargazerAccount is the profile of a stargazer when it's fetched.
+	StargazerAccount struct {
+		StarredAt   time.Time `json:"starredAt"`
+		CreatedAt   time.Time `json:"createdAt"`
+		Followers   int       `json:"followers"`
+		Following   int       `json:"following"`
+		PublicRepos int       `json:"publicRepos"`
+		HasBio      bool      `json:"hasBio"`
+	}
+
+	// PublishedRelease is a release or a tag, Downloads is the sum of the
+	// downloads of the assets.
+	PublishedRelease struct {
+		Tag         string    `json:"tag"`
+		Prerelease  bool      `json:"prerelease,omitempty"`
+		PublishedAt time.Time `json:"publishedAt"`
+		Downloads   int       `json:"downloads,omitempty"`
+	}
+
+	// LanguageShare is the size of a language in a repository, Percent is in
+	// the range [0, 100].
+	LanguageShare struct {
+		Name    string  `json:"name"`
+		Color   string  `json:"color,omitempty"`
+		Bytes   int     `json:"bytes"`
+		Percent float64 `json:"percent"`
+	}
+
+	// AuthoredCommit is the author of a commit, AuthoredAt keeps the time
+	// zone of the author if it's known.
+	AuthoredCommit struct {
+		Author       string    `json:"author"`
+		Organization string    `json:"organization,omitempty"`
+		AuthoredAt   time.Time `json:"authoredAt"`
+	}
+
+	// PullLifecycle is the milestones of a pull request, the zero times mean
+	// the milestones are not reached.
+	PullLifecycle struct {
+		CreatedAt            time.Time `json:"createdAt"`
+		MergedAt             time.Time `json:"mergedAt"`
+		ClosedAt             time.Time `json:"closedAt"`
+		FirstResponseAt      time.Time `json:"firstResponseAt"`
+		FirstTimeContributor bool      `json:"firstTimeContributor"`
+	}
+
+	// IssueLifecycle is the milestones of an issue, Stale reports whether it's
+	// closed with a stale label.
+	IssueLifecycle struct {
+		CreatedAt       time.Time `json:"createdAt"`
+		ClosedAt        time.Time `json:"closedAt"`
+		FirstResponseAt time.Time `json:"firstResponseAt"`
+		Stale           bool      `json:"stale"`
+	}
+
+	// Windows are the periods of the time series in Result.
+	Windows struct {
+		Stars     time.Duration `json:"stars" yaml:"stars"`
+		Activity  time.Duration `json:"activity" yaml:"activity"`
+		Lifecycle time.Duration `json:"lifecycle" yaml:"lifecycle"`
+		History   time.Duration `json:"history" yaml:"history"`
+	}
+
+	// Config configures Fetch and FetchExternal.
+	Config struct {
+		// Client is an authorized client of the GitHub API.
+		Client *http.Client
+		// Transport is used by the clients of GitLab and Gitea, nil means
+		// http.DefaultTransport.
+		Transport http.RoundTripper
+		// Windows is DefaultWindows if it's zero.
+		Windows Windows
+		// Detail fetches the forks of GitHub repositories and fills
+		// Result.Issues too.
+		Detail bool
+		// GitLabToken and GiteaToken authorize the requests of GitLab and
+		// Gitea, GITLAB_ACCESS_TOKEN and GITEA_ACCESS_TOKEN are read if they
+		// are empty.
+		GitLabToken string
+		GiteaToken  string
+	}
+)
+
+// DefaultWindows returns a month for stars, a week for the activities, a
+// quarter for the lifecycles and a year for the commit history.
+func DefaultWindows() Windows {
+	return Windows{Stars: timeMonth, Activity: timeWeek, Lifecycle: timeQuarter,
+		History: timeYear}
+}
+
+func (c Config) windows() Windows {
+	return c.Windows.OrDefault()
+}
+
+func (c Config) gitlabToken() string {
+	if len(c.GitLabToken) > 0 {
+		return c.GitLabToken
+	}
+	return os.Getenv("GITLAB_ACCESS_TOKEN")
+}
+
+func (c Config) giteaToken() string {
+	if len(c.GiteaToken) > 0 {
+		return c.GiteaToken
+	}
+	return os.Getenv("GITEA_ACCESS_TOKEN")
+}
+
+// OrDefault fills the zero periods of w with the ones of DefaultWindows.
+func (w Windows) OrDefault() Windows {
+	d := DefaultWindows()
+	if w.Stars <= 0 {
+		w.Stars = d.Stars
+	}
+	if w.Activity <= 0 {
+		w.Activity = d.Activity
+	}
+	if w.Lifecycle <= 0 {
+		w.Lifecycle = d.Lifecycle
+	}
+	if w.History <= 0 {
+		w.History = d.History
+	}
+	return w
+}
+
+// longest returns the longer one of Activity and Lifecycle.
+func (w Windows) longest() time.Duration {
+	if w.Activity > w.Lifecycle {
+		return w.Activity
+	}
+	return w.Lifecycle
+}
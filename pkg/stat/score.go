@@ -0,0 +1,239 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ScoreStarVelocity   = "starVelocity"
+	ScoreCommitVelocity = "commitVelocity"
+	ScorePullVelocity   = "pullVelocity"
+	ScoreIssueOpenRatio = "issueOpenRatio"
+	ScoreReleaseCadence = "releaseCadence"
+	ScorePushRecency    = "pushRecency"
+	ScoreContributors   = "contributors"
+	ScoreLicense        = "license"
+)
+
+// ScoreItems lists the score components in display order.
+var ScoreItems = []string{
+	ScoreStarVelocity,
+	ScoreCommitVelocity,
+	ScorePullVelocity,
+	ScoreIssueOpenRatio,
+	ScoreReleaseCadence,
+	ScorePushRecency,
+	ScoreContributors,
+	ScoreLicense,
+}
+
+type (
+	// ScoreWeights maps a score component to its relative weight.
+	ScoreWeights map[string]float64
+
+	Score struct {
+		Total     float64            `json:"total"`
+		Breakdown map[string]float64 `json:"breakdown"`
+	}
+)
+
+func DefaultScoreWeights() ScoreWeights {
+	return ScoreWeights{
+		ScoreStarVelocity:   2,
+		ScoreCommitVelocity: 2,
+		ScorePullVelocity:   1,
+		ScoreIssueOpenRatio: 1,
+		ScoreReleaseCadence: 1,
+		ScorePushRecency:    2,
+		ScoreContributors:   2,
+		ScoreLicense:        1,
+	}
+}
+
+// LoadScoreWeights reads weights from a yaml file, components missing from
+// the file keep their default weight.
+func LoadScoreWeights(file string) (ScoreWeights, error) {
+	weights := DefaultScoreWeights()
+	if len(file) == 0 {
+		return weights, nil
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]float64
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	for k, v := range m {
+		if _, ok := weights[k]; !ok {
+			return nil, fmt.Errorf("unknown score component %q", k)
+		}
+		if v < 0 {
+			return nil, fmt.Errorf("negative weight %v of %q", v, k)
+		}
+		weights[k] = v
+	}
+
+	return weights, nil
+}
+
+// Rate computes a 0-100 score for each repository, every metric is
+// normalised across the given list so the score is relative to the set.
+func Rate(list []Data, weights ScoreWeights) {
+	var totalWeight float64
+	for _, k := range ScoreItems {
+		totalWeight += weights[k]
+	}
+	if totalWeight == 0 || len(list) == 0 {
+		return
+	}
+
+	normalized := make(map[string][]float64, len(ScoreItems))
+	for _, k := range ScoreItems {
+		normalized[k] = normalize(k, list)
+	}
+
+	for i := range list {
+		score := Score{Breakdown: make(map[string]float64, len(ScoreItems))}
+		for _, k := range ScoreItems {
+			point := normalized[k][i] * weights[k] / totalWeight * 100
+			score.Breakdown[k] = round(point)
+			score.Total += point
+		}
+		score.Total = round(score.Total)
+		list[i].Score = &score
+	}
+}
+
+func normalize(item string, list []Data) []float64 {
+	values := make([]float64, len(list))
+	switch item {
+	case ScoreIssueOpenRatio:
+		// the unknown ratios get nothing
+		for i, e := range list {
+			if ratio := e.Metrics.OpenIssueRatio(); !math.IsNaN(ratio) {
+				values[i] = 1 - ratio
+			}
+		}
+		return values
+	case ScoreLicense:
+		for i, e := range list {
+			if e.Metrics.HasLicense {
+				values[i] = 1
+			}
+		}
+		return values
+	case ScoreReleaseCadence:
+		// repositories without any release get nothing, the shortest
+		// average period gets the full mark.
+		var valid []int
+		for i, e := range list {
+			if e.Metrics.ReleaseCount > 0 {
+				values[i] = e.Metrics.AvgReleasePeriodDays
+				valid = append(valid, i)
+			}
+		}
+		ret := make([]float64, len(list))
+		for i, v := range minMax(pick(values, valid), true) {
+			ret[valid[i]] = v
+		}
+		return ret
+	case ScorePushRecency:
+		for i, e := range list {
+			values[i] = e.Metrics.DaysSincePush()
+		}
+		return minMax(values, true)
+	}
+
+	for i, e := range list {
+		switch item {
+		case ScoreStarVelocity:
+			values[i] = e.Metrics.StarsPerDay()
+		case ScoreCommitVelocity:
+			values[i] = float64(e.Metrics.LatestWeekCommitCount)
+		case ScorePullVelocity:
+			values[i] = float64(e.Metrics.LatestWeekPullCount)
+		case ScoreContributors:
+			values[i] = count(e.Metrics.ContributorCount)
+		}
+		// dampen the long tail so that a single outlier does not flatten the others
+		values[i] = math.Log1p(values[i])
+	}
+
+	return minMax(values, false)
+}
+
+func pick(values []float64, index []int) []float64 {
+	var ret []float64
+	for _, i := range index {
+		ret = append(ret, values[i])
+	}
+	return ret
+}
+
+// minMax scales values into 0-1, the unknown values(NaN) get 0 and are left
+// out of the range.
+func minMax(values []float64, lowerIsBetter bool) []float64 {
+	if len(values) == 0 {
+		return values
+	}
+
+	minVal, maxVal := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			minVal = math.Min(minVal, v)
+			maxVal = math.Max(maxVal, v)
+		}
+	}
+
+	ret := make([]float64, len(values))
+	for i, v := range values {
+		switch {
+		case math.IsNaN(v):
+		case maxVal == minVal:
+			if lowerIsBetter || v > 0 {
+				ret[i] = 1
+			}
+		case lowerIsBetter:
+			ret[i] = (maxVal - v) / (maxVal - minVal)
+		default:
+			ret[i] = (v - minVal) / (maxVal - minVal)
+		}
+	}
+
+	return ret
+}
+
+func round(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,101 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRate(t *testing.T) {
+	now := time.Now()
+	list := []Data{
+		{FullName: "a/a", Metrics: Metrics{StarCount: 1000, AgeDays: 100, ContributorCount: 50,
+			LatestWeekCommitCount: 30, LatestWeekPullCount: 10, IssueCount: 10, OpenIssueCount: 0,
+			ReleaseCount: 10, AvgReleasePeriodDays: 10, HasLicense: true, PushedAt: now}},
+		{FullName: "b/b", Metrics: Metrics{StarCount: 10, AgeDays: 100, ContributorCount: 1,
+			IssueCount: 10, OpenIssueCount: 9, PushedAt: now.Add(-100 * timeDay)}},
+	}
+
+	Rate(list, DefaultScoreWeights())
+	if list[0].Score.Total != 100 {
+		t.Fatalf("expected 100, got %v", list[0].Score.Total)
+	}
+	if list[1].Score.Total >= list[0].Score.Total {
+		t.Fatalf("expected b/b to score lower, got %v", list[1].Score.Total)
+	}
+
+	var sum float64
+	for _, v := range list[1].Score.Breakdown {
+		sum += v
+	}
+	if d := sum - list[1].Score.Total; d > 0.5 || d < -0.5 {
+		t.Fatalf("breakdown %v does not add up to %v", sum, list[1].Score.Total)
+	}
+}
+
+func TestRateUnknown(t *testing.T) {
+	now := time.Now()
+	list := []Data{
+		{FullName: "a/a", Metrics: Metrics{StarCount: 10, AgeDays: 10, ContributorCount: 2,
+			IssueCount: 10, OpenIssueCount: 5, PushedAt: now.Add(-30 * timeDay)}},
+		{FullName: "b/b", Metrics: Metrics{StarCount: Unknown, ContributorCount: Unknown,
+			IssueCount: Unknown, OpenIssueCount: Unknown}},
+	}
+
+	Rate(list, DefaultScoreWeights())
+	for _, k := range []string{ScoreStarVelocity, ScoreIssueOpenRatio, ScorePushRecency,
+		ScoreContributors} {
+		if v := list[1].Score.Breakdown[k]; v != 0 {
+			t.Fatalf("expected nothing for the unknown %s, got %v", k, v)
+		}
+		if v := list[0].Score.Breakdown[k]; v == 0 {
+			t.Fatalf("expected points for the known %s", k)
+		}
+	}
+}
+
+func TestLoadScoreWeights(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "weights.yaml")
+	if err := ioutil.WriteFile(file, []byte("license: 5\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	weights, err := LoadScoreWeights(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weights[ScoreLicense] != 5 || weights[ScoreStarVelocity] != 2 {
+		t.Fatalf("unexpected weights: %v", weights)
+	}
+
+	if err := ioutil.WriteFile(file, []byte("unknown: 1\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadScoreWeights(file); err == nil {
+		t.Fatal("expected error for unknown component")
+	}
+}
@@ -0,0 +1,334 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	SortStars          = "stars"
+	SortForks          = "forks"
+	SortWatchers       = "watchers"
+	SortOpenIssueRatio = "openIssueRatio"
+	SortPulls          = "pulls"
+	SortContributors   = "contributors"
+	SortReleases       = "releases"
+	SortReleasePeriod  = "releasePeriod"
+	SortAge            = "age"
+	SortLastPush       = "lastPush"
+	SortLastRelease    = "lastRelease"
+	SortLastUpdate     = "lastUpdate"
+	SortDayStars       = "dayStars"
+	SortWeekStars      = "weekStars"
+	SortMonthStars     = "monthStars"
+	SortWeekCommits    = "weekCommits"
+	SortWeekPulls      = "weekPulls"
+	SortScore          = "score"
+	SortPullMergeRatio = "pullMergeRatio"
+	SortPullMergeTime  = "pullMergeTime"
+	SortPullResponse   = "pullResponse"
+	SortFirstTimePulls = "firstTimePulls"
+	SortIssueResponse  = "issueResponse"
+	SortIssueCloseTime = "issueCloseTime"
+	SortStaleIssues    = "staleIssues"
+	SortIssueBacklog   = "issueBacklog"
+	SortTopContributor = "topContributor"
+	SortBusFactor      = "busFactor"
+	SortMaintainers    = "maintainers"
+	SortOrganizations  = "organizations"
+	SortReleaseGap     = "releaseInterval"
+	SortStableRelease  = "lastStableRelease"
+	SortDownloads      = "downloads"
+	SortLowSignalStars = "lowSignalStars"
+	SortStarBursts     = "starBursts"
+	SortCommunity      = "communityHealth"
+	SortArchived       = "archived"
+	SortStarForecast   = "starForecast"
+	SortForkForecast   = "forkForecast"
+)
+
+type metric struct {
+	value         func(Data) float64
+	lowerIsBetter bool
+}
+
+var metrics = map[string]metric{
+	SortStars:    {value: func(d Data) float64 { return count(d.Metrics.StarCount) }},
+	SortForks:    {value: func(d Data) float64 { return count(d.Metrics.ForkCount) }},
+	SortWatchers: {value: func(d Data) float64 { return count(d.Metrics.WatcherCount) }},
+	SortOpenIssueRatio: {
+		value:         func(d Data) float64 { return d.Metrics.OpenIssueRatio() },
+		lowerIsBetter: true,
+	},
+	SortPulls:        {value: func(d Data) float64 { return count(d.Metrics.PullCount) }},
+	SortContributors: {value: func(d Data) float64 { return count(d.Metrics.ContributorCount) }},
+	SortReleases:     {value: func(d Data) float64 { return count(d.Metrics.ReleaseCount) }},
+	SortReleasePeriod: {
+		value: func(d Data) float64 {
+			if d.Metrics.ReleaseCount <= 0 {
+				return math.Inf(1)
+			}
+			return d.Metrics.AvgReleasePeriodDays
+		},
+		lowerIsBetter: true,
+	},
+	SortAge:         {value: func(d Data) float64 { return float64(d.Metrics.AgeDays) }},
+	SortLastPush:    {value: func(d Data) float64 { return unix(d.Metrics.PushedAt.Unix()) }},
+	SortLastRelease: {value: func(d Data) float64 { return unix(d.Metrics.LatestReleaseAt.Unix()) }},
+	SortLastUpdate:  {value: func(d Data) float64 { return unix(d.Metrics.UpdatedAt.Unix()) }},
+	SortDayStars:    {value: func(d Data) float64 { return float64(d.Metrics.LatestDayStarCount) }},
+	SortWeekStars:   {value: func(d Data) float64 { return float64(d.Metrics.LatestWeekStarCount) }},
+	SortMonthStars:  {value: func(d Data) float64 { return float64(d.Metrics.LatestMonthStarCount) }},
+	SortWeekCommits: {value: func(d Data) float64 { return float64(d.Metrics.LatestWeekCommitCount) }},
+	SortWeekPulls:   {value: func(d Data) float64 { return float64(d.Metrics.LatestWeekPullCount) }},
+	SortPullMergeTime: {
+		value: func(d Data) float64 {
+			if d.Metrics.MergedPullCount == 0 {
+				return math.Inf(1)
+			}
+			return d.Metrics.MedianPullMergeHours
+		},
+		lowerIsBetter: true,
+	},
+	SortPullMergeRatio: {value: func(d Data) float64 { return d.Metrics.MergedPullRatio }},
+	SortPullResponse: {
+		value: func(d Data) float64 {
+			if d.Metrics.RespondedPullCount == 0 {
+				return math.Inf(1)
+			}
+			return d.Metrics.MedianPullResponseHours
+		},
+		lowerIsBetter: true,
+	},
+	SortFirstTimePulls: {value: func(d Data) float64 { return d.Metrics.FirstTimePullShare }},
+	SortIssueResponse: {
+		value: func(d Data) float64 {
+			if d.Metrics.RespondedIssueCount == 0 {
+				return math.Inf(1)
+			}
+			return d.Metrics.MedianIssueResponseHours
+		},
+		lowerIsBetter: true,
+	},
+	SortIssueCloseTime: {
+		value: func(d Data) float64 {
+			if d.Metrics.ClosedIssueCount == 0 {
+				return math.Inf(1)
+			}
+			return d.Metrics.MedianIssueCloseHours
+		},
+		lowerIsBetter: true,
+	},
+	SortStaleIssues: {
+		value:         func(d Data) float64 { return d.Metrics.StaleIssueRatio },
+		lowerIsBetter: true,
+	},
+	SortIssueBacklog: {
+		value:         func(d Data) float64 { return float64(d.Metrics.IssueBacklogGrowth) },
+		lowerIsBetter: true,
+	},
+	SortTopContributor: {
+		value: func(d Data) float64 {
+			if d.Metrics.HistoryCommitCount == 0 {
+				return math.Inf(1)
+			}
+			return d.Metrics.TopContributorShare
+		},
+		lowerIsBetter: true,
+	},
+	SortBusFactor:     {value: func(d Data) float64 { return float64(d.Metrics.BusFactor) }},
+	SortMaintainers:   {value: func(d Data) float64 { return float64(d.Metrics.ActiveMaintainerCount) }},
+	SortOrganizations: {value: func(d Data) float64 { return float64(d.Metrics.OrganizationCount) }},
+	SortReleaseGap: {
+		value: func(d Data) float64 {
+			if d.Metrics.StableReleaseCount < 2 {
+				return math.Inf(1)
+			}
+			return d.Metrics.MedianReleaseIntervalDays
+		},
+		lowerIsBetter: true,
+	},
+	SortStableRelease: {value: func(d Data) float64 {
+		return unix(d.Metrics.LatestStableReleaseAt.Unix())
+	}},
+	SortDownloads: {value: func(d Data) float64 { return float64(d.Metrics.ReleaseDownloads) }},
+	SortLowSignalStars: {
+		value: func(d Data) float64 {
+			if d.Metrics.StargazerAccountCount == 0 {
+				return math.Inf(1)
+			}
+			return d.Metrics.LowSignalStarShare
+		},
+		lowerIsBetter: true,
+	},
+	SortStarBursts: {
+		value:         func(d Data) float64 { return float64(d.Metrics.StarBurstDays) },
+		lowerIsBetter: true,
+	},
+	SortCommunity: {value: func(d Data) float64 { return float64(d.Metrics.CommunityHealth) }},
+	SortArchived: {
+		value: func(d Data) float64 {
+			if d.Metrics.Archived {
+				return 1
+			}
+			return 0
+		},
+		lowerIsBetter: true,
+	},
+	SortStarForecast: {value: func(d Data) float64 { return float64(d.Metrics.ProjectedStarCount) }},
+	SortForkForecast: {value: func(d Data) float64 { return float64(d.Metrics.ProjectedForkCount) }},
+	SortScore: {value: func(d Data) float64 {
+		if d.Score == nil {
+			return 0
+		}
+		return d.Score.Total
+	}},
+}
+
+// count converts a count of the source, it's NaN if the count is Unknown.
+func count(v int) float64 {
+	if v < 0 {
+		return math.NaN()
+	}
+	return float64(v)
+}
+
+func unix(sec int64) float64 {
+	if sec < 0 {
+		return 0
+	}
+	return float64(sec)
+}
+
+// Value returns the value of the metric key, time based metrics are unix
+// seconds, the value is NaN if it's unknown.
+func Value(d Data, key string) (float64, bool) {
+	m, ok := metrics[key]
+	if !ok {
+		return 0, false
+	}
+	return m.value(d), true
+}
+
+func SortKeys() []string {
+	var keys []string
+	for k := range metrics {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func CheckSortKey(key string) error {
+	if _, ok := metrics[key]; !ok {
+		return fmt.Errorf("invalid sort key %q, expected one of: %s", key,
+			strings.Join(SortKeys(), ", "))
+	}
+	return nil
+}
+
+// Sort sorts the list by the value of key and fills Rank of each element, the
+// rank always starts from the best value regardless of the order.
+func Sort(list []Data, key string, asc bool) error {
+	if err := CheckSortKey(key); err != nil {
+		return err
+	}
+
+	m := metrics[key]
+	sort.SliceStable(list, func(i, j int) bool {
+		a, b := m.value(list[i]), m.value(list[j])
+		// the unknown values come last in either order
+		if math.IsNaN(a) || math.IsNaN(b) {
+			return !math.IsNaN(a) && math.IsNaN(b)
+		}
+		if asc {
+			return a < b
+		}
+		return a > b
+	})
+
+	for i := range list {
+		rank := 1
+		for j := range list {
+			if m.better(m.value(list[j]), m.value(list[i])) {
+				rank++
+			}
+		}
+		list[i].Rank = rank
+	}
+
+	return nil
+}
+
+// Best returns the indexes of the elements which hold the best value of key,
+// it returns nil if the key is unknown or all the values are the same.
+func Best(list []Data, key string) []int {
+	m, ok := metrics[key]
+	if !ok || len(list) < 2 {
+		return nil
+	}
+
+	var (
+		first   = m.value(list[0])
+		best    = first
+		allSame = true
+	)
+	for _, e := range list[1:] {
+		v := m.value(e)
+		if v != first && !(math.IsNaN(v) && math.IsNaN(first)) {
+			allSame = false
+		}
+		if m.better(v, best) {
+			best = v
+		}
+	}
+	if allSame || math.IsNaN(best) {
+		return nil
+	}
+
+	var ret []int
+	for i, e := range list {
+		if m.value(e) == best {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
+
+// better tells whether a is better than b, an unknown value is the worst.
+func (m metric) better(a, b float64) bool {
+	switch {
+	case math.IsNaN(a):
+		return false
+	case math.IsNaN(b):
+		return true
+	case m.lowerIsBetter:
+		return a < b
+	default:
+		return a > b
+	}
+}
@@ -0,0 +1,87 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	list := []Data{
+		{FullName: "a/a", Metrics: Metrics{StarCount: 10, IssueCount: 10, OpenIssueCount: 5}},
+		{FullName: "b/b", Metrics: Metrics{StarCount: 30, IssueCount: 10, OpenIssueCount: 1}},
+		{FullName: "c/c", Metrics: Metrics{StarCount: 20, IssueCount: 10, OpenIssueCount: 1}},
+	}
+
+	if err := Sort(list, SortStars, false); err != nil {
+		t.Fatal(err)
+	}
+	if list[0].FullName != "b/b" || list[2].FullName != "a/a" || list[0].Rank != 1 {
+		t.Fatalf("unexpected order: %v", list)
+	}
+
+	if err := Sort(list, SortOpenIssueRatio, false); err != nil {
+		t.Fatal(err)
+	}
+	if list[0].FullName != "a/a" || list[0].Rank != 3 || list[1].Rank != 1 || list[2].Rank != 1 {
+		t.Fatalf("unexpected rank: %v", list)
+	}
+
+	if best := Best(list, SortOpenIssueRatio); len(best) != 2 {
+		t.Fatalf("expected 2 winners, got %v", best)
+	}
+	if err := Sort(list, "unknown", false); err == nil {
+		t.Fatal("expected error for unknown key")
+	}
+}
+
+func TestSortUnknown(t *testing.T) {
+	list := []Data{
+		{FullName: "a/a", Metrics: Metrics{ContributorCount: Unknown}},
+		{FullName: "b/b", Metrics: Metrics{ContributorCount: 3, IssueCount: 10, OpenIssueCount: 5}},
+		{FullName: "c/c", Metrics: Metrics{ContributorCount: 1, IssueCount: 10, OpenIssueCount: 1}},
+	}
+
+	for _, asc := range []bool{false, true} {
+		if err := Sort(list, SortContributors, asc); err != nil {
+			t.Fatal(err)
+		}
+		if list[2].FullName != "a/a" || list[2].Rank != 3 {
+			t.Fatalf("expected the unknown value last: %v", list)
+		}
+	}
+
+	if err := Sort(list, SortOpenIssueRatio, false); err != nil {
+		t.Fatal(err)
+	}
+	if list[2].FullName != "a/a" || list[2].Rank != 3 || list[0].FullName != "b/b" {
+		t.Fatalf("expected the unknown ratio last: %v", list)
+	}
+	if best := Best(list, SortOpenIssueRatio); len(best) != 1 || list[best[0]].FullName != "c/c" {
+		t.Fatalf("unexpected winners: %v", best)
+	}
+	if v, ok := Value(list[2], SortContributors); !ok || !math.IsNaN(v) {
+		t.Fatalf("expected an unknown value, got %v", v)
+	}
+}
@@ -25,16 +25,28 @@ package stat
 import (
 	"time"
 
-	"github.com/anqiansong/github-compare/pkg/timex"
 	"github.com/shurcooL/githubv4"
 )
 
+// maxStargazerPages limits the stargazers within the window to the latest
+// 5000.
+const maxStargazerPages = 50
+
 type (
 	StargazerEdges []StargazerEdge
 
 	StargazerEdge struct {
 		Cursor    githubv4.String
 		StarredAt githubv4.DateTime
+		Node      StargazerUser
+	}
+
+	StargazerUser struct {
+		CreatedAt    githubv4.DateTime
+		Bio          githubv4.String
+		Followers    CountConnection
+		Following    CountConnection
+		Repositories CountConnection `graphql:"repositories(privacy: PUBLIC)"`
 	}
 
 	StargazerConnection struct {
@@ -52,90 +64,50 @@ type (
 	}
 )
 
-func (s StargazerEdges) Chart() Chart {
-
-	var (
-		labels  []string
-		data    []float64
-		now     = time.Now()
-		dayTime = timex.AllDays(now.Add(-monthDur), now)
-	)
-
-	for _, t := range dayTime {
-		label := t.Format(labelLayout)
-		labels = append(labels, label)
-		data = append(data, float64(s.getSpecifiedDate(t)))
+// times returns the times of the stars, it's nil if the stargazers are
+// unknown.
+func (s StargazerEdges) times() timeList {
+	if s == nil {
+		return nil
 	}
 
-	return Chart{Data: data, Labels: labels}
-}
-
-func (s StargazerEdges) getSpecifiedDate(date time.Time) int {
-	var (
-		count int
-		zero  = timex.Truncate(date)
-	)
-
+	list := make(timeList, 0, len(s))
 	for _, e := range s {
-		if timex.Truncate(e.StarredAt.Time).Equal(zero) {
-			count += 1
-		}
+		list = append(list, e.StarredAt.Time)
 	}
-
-	return count
+	return list
 }
 
-func (s StargazerEdges) LatestDayStars() (int, int) {
-	var (
-		starsOfToday        int
-		starsOfYesterday    int
-		deadlineOfToday     = time.Now().Add(-time.Hour * 24)
-		deadlineOfYesterday = deadlineOfToday.Add(-time.Hour * 24)
-	)
-
-	for _, e := range s {
-		if e.StarredAt.Time.After(deadlineOfToday) {
-			starsOfToday += 1
-		}
-		if e.StarredAt.Time.Before(deadlineOfToday) && e.StarredAt.Time.After(deadlineOfYesterday) {
-			starsOfYesterday += 1
-		}
-
+// accounts returns the profiles of the stargazers, it's nil if the stargazers
+// are unknown.
+func (s StargazerEdges) accounts() []StargazerAccount {
+	if s == nil {
+		return nil
 	}
-	return starsOfToday, starsOfToday - starsOfYesterday
-}
-
-func (s StargazerEdges) LatestWeekStars() (int, int) {
-	var (
-		starsOfLatest7Days    int
-		starsOfPre7Days       int
-		deadlineOfLatest7Days = time.Now().Add(-timeWeek)
-		deadlineOfPre7Days    = deadlineOfLatest7Days.Add(-timeWeek)
-	)
 
+	list := make([]StargazerAccount, 0, len(s))
 	for _, e := range s {
-		if e.StarredAt.Time.After(deadlineOfLatest7Days) {
-			starsOfLatest7Days += 1
-		}
-		if e.StarredAt.Time.Before(deadlineOfLatest7Days) && e.StarredAt.Time.After(deadlineOfPre7Days) {
-			starsOfPre7Days += 1
-		}
+		list = append(list, StargazerAccount{
+			StarredAt:   e.StarredAt.Time,
+			CreatedAt:   e.Node.CreatedAt.Time,
+			Followers:   int(e.Node.Followers.TotalCount),
+			Following:   int(e.Node.Following.TotalCount),
+			PublicRepos: int(e.Node.Repositories.TotalCount),
+			HasBio:      len(e.Node.Bio) > 0,
+		})
 	}
-
-	return starsOfLatest7Days, starsOfLatest7Days - starsOfPre7Days
-}
-
-func (s StargazerEdges) LatestMonthStars() int {
-	return len(s)
+	return list
 }
 
-func (s Stat) latestMonthStargazers() StargazerEdges {
+// stargazers fetches at most maxStargazerPages of the stargazers within
+// Windows.Stars, it's nil if the query fails. truncated tells whether there are
+// more stargazers within the window.
+func (s Stat) stargazers() (list StargazerEdges, truncated bool) {
 	var (
 		brk            bool
 		stargazerQuery StargazerQuery
-		list           []StargazerEdge
 		after          githubv4.String
-		deadline       = time.Now().Add(-timeMonth)
+		deadline       = time.Now().Add(-s.windows.Stars)
 	)
 
 	arg := map[string]interface{}{
@@ -148,9 +120,16 @@ func (s Stat) latestMonthStargazers() StargazerEdges {
 		},
 	}
 
-	for {
-		_ = s.graphqlClient.Query(s.ctx, &stargazerQuery, arg)
+	for page := 1; page <= maxStargazerPages; page++ {
+		s.reportPage(StageStargazers, page)
+		if err := s.graphqlClient.Query(s.ctx, &stargazerQuery, arg); err != nil {
+			s.reportDone(StageStargazers, err)
+			return nil, false
+		}
 		temp := stargazerQuery.Stargazer.Stargazers.Edges
+		if list == nil {
+			list = make(StargazerEdges, 0, len(temp))
+		}
 
 		for _, e := range temp {
 			if e.StarredAt.Time.Before(deadline) {
@@ -162,10 +141,12 @@ func (s Stat) latestMonthStargazers() StargazerEdges {
 		if brk || !(bool)(stargazerQuery.Stargazer.Stargazers.PageInfo.HasNextPage) || len(temp) == 0 {
 			break
 		}
+		truncated = page == maxStargazerPages
 
 		after = temp[len(temp)-1].Cursor
 		arg["after"] = after
 	}
 
-	return list
+	s.reportDone(StageStargazers, nil)
+	return list, truncated
 }
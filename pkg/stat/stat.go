@@ -24,15 +24,11 @@ package stat
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"os"
+	"net/http"
 	"strings"
-	"time"
 
 	"github.com/google/go-github/v44/github"
 	"github.com/shurcooL/githubv4"
-	"golang.org/x/oauth2"
 )
 
 type (
@@ -41,6 +37,7 @@ type (
 		repo          string
 		graphqlClient *githubv4.Client
 		restClient    *github.Client
+		windows       Windows
 		ctx           context.Context
 	}
 
@@ -52,21 +49,23 @@ type (
 	}
 )
 
-func NewStat(repo string, accessToken ...string) *Stat {
-	token := getAccessToken(accessToken...)
-	if len(token) == 0 {
-		log.Fatalln("missing access token")
-	}
+func newClients(httpClient *http.Client) (*githubv4.Client, *github.Client) {
+	return githubv4.NewClient(httpClient), github.NewClient(httpClient)
+}
 
-	ctx := context.Background()
-	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
-	httpClient := oauth2.NewClient(ctx, ts)
-	splits := strings.Split(repo, "/")
-	graphqlClient := githubv4.NewClient(httpClient)
-	restClient := github.NewClient(httpClient)
+func newStat(ctx context.Context, repo string, graphqlClient *githubv4.Client,
+	restClient *github.Client, windows Windows) *Stat {
+	owner, name := splitRepo(repo)
+	return &Stat{owner: owner, repo: name, graphqlClient: graphqlClient,
+		restClient: restClient, windows: windows, ctx: ctx}
+}
 
-	return &Stat{owner: splits[0], repo: splits[1], graphqlClient: graphqlClient,
-		restClient: restClient, ctx: context.Background()}
+func splitRepo(repo string) (string, string) {
+	splits := strings.SplitN(repo, "/", 2)
+	if len(splits) < 2 {
+		return repo, ""
+	}
+	return splits[0], splits[1]
 }
 
 func (s Stat) GetTotal(resp *github.Response) int {
@@ -75,48 +74,3 @@ func (s Stat) GetTotal(resp *github.Response) int {
 	}
 	return resp.LastPage
 }
-
-func getAccessToken(accessToken ...string) string {
-	for _, e := range accessToken {
-		if len(e) > 0 {
-			return e
-		}
-	}
-
-	return os.Getenv("GITHUB_ACCESS_TOKEN")
-}
-
-func formatPeriod(duration time.Duration) string {
-	if duration == 0 {
-		return "N/A"
-	}
-	hours := duration.Hours()
-
-	return fmt.Sprintf("%d days", int(hours/float64(24)))
-}
-
-func formatDuration(at time.Time) string {
-	if at.IsZero() {
-		return "N/A"
-	}
-
-	duration := time.Since(at)
-	hours := int(duration.Hours())
-	minutes := int(duration.Minutes())
-
-	if hours == 0 && minutes == 0 && duration.Seconds() < float64(60) {
-		return fmt.Sprintf("%v seconds(s) ago", int(duration.Seconds()))
-	}
-	switch {
-	case hours < hour:
-		return fmt.Sprintf("%v minute(s) ago", minutes)
-	case hours < day:
-		return fmt.Sprintf("%v hour(s) ago", hours)
-	case hours < month:
-		return fmt.Sprintf("%v day(s) ago", hours/day)
-	case hours < year:
-		return fmt.Sprintf("%v month(s) ago", hours/month)
-	default:
-		return at.Format("2006-01-02")
-	}
-}
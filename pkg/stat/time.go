@@ -22,7 +22,11 @@
 
 package stat
 
-import "time"
+import (
+	"time"
+
+	"github.com/anqiansong/github-compare/pkg/timex"
+)
 
 const (
 	labelLayout = "02"
@@ -32,10 +36,70 @@ const (
 	month = 30 * day
 	year  = 12 * month
 
-	weekDur  = 6 * timeDay
-	monthDur = 29 * timeDay
-
-	timeDay   = 24 * time.Hour
-	timeWeek  = 7 * timeDay
-	timeMonth = 30 * timeDay
+	timeDay     = 24 * time.Hour
+	timeWeek    = 7 * timeDay
+	timeMonth   = 30 * timeDay
+	timeQuarter = 90 * timeDay
+	timeYear    = 365 * timeDay
 )
+
+// timeList is the times of events, e.g. stars and commits.
+type timeList []time.Time
+
+// chart counts the events per day of the latest window, it's empty if the
+// times are unknown.
+func (t timeList) chart(window time.Duration) Chart {
+	if t == nil {
+		return Chart{}
+	}
+
+	var (
+		labels  []string
+		data    []float64
+		now     = time.Now()
+		dayTime = timex.AllDays(now.Add(-window+timeDay), now)
+	)
+
+	for _, d := range dayTime {
+		labels = append(labels, d.Format(labelLayout))
+		data = append(data, float64(t.countOfDate(d)))
+	}
+
+	return Chart{Data: data, Labels: labels}
+}
+
+func (t timeList) countOfDate(date time.Time) int {
+	var (
+		count int
+		zero  = timex.Truncate(date)
+	)
+
+	for _, e := range t {
+		if timex.Truncate(e).Equal(zero) {
+			count += 1
+		}
+	}
+
+	return count
+}
+
+// StarTrend counts the stars of the latest period and the difference from
+// the period before.
+func StarTrend(stargazers []time.Time, period time.Duration) (int, int) {
+	var (
+		latest, previous int
+		latestDeadline   = time.Now().Add(-period)
+		previousDeadline = latestDeadline.Add(-period)
+	)
+
+	for _, e := range stargazers {
+		if e.After(latestDeadline) {
+			latest += 1
+		}
+		if e.Before(latestDeadline) && e.After(previousDeadline) {
+			previous += 1
+		}
+	}
+
+	return latest, latest - previous
+}
@@ -0,0 +1,216 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anqiansong/github-compare/pkg/timex"
+)
+
+const (
+	// baselineDays is the days of the rolling baseline before a day.
+	baselineDays = 7
+	// minBaselineDays is the fewest days to make a baseline.
+	minBaselineDays = 3
+	// anomalyScore is the z-score which an anomalous day reaches.
+	anomalyScore = 3
+	// minAnomalyChange is the least change from the baseline of an anomalous
+	// day, it keeps the quiet series from the noise.
+	minAnomalyChange = 5
+	// releaseNearby is how close a release is to be the hint of an anomaly.
+	releaseNearby = 2 * timeDay
+)
+
+const (
+	TrendSpike = 1
+	TrendDrop  = -1
+)
+
+// Anomaly is a day whose count deviates from the rolling baseline of the days
+// before it.
+type Anomaly struct {
+	Metric   string    `json:"metric"`
+	Date     time.Time `json:"date"`
+	Count    int       `json:"count"`
+	Baseline float64   `json:"baseline"`
+	Score    float64   `json:"score"`
+	Hint     string    `json:"hint,omitempty"`
+}
+
+// detectAnomalies compares every day of a daily chart which ends today with
+// the baselineDays before it, which leaves out the anomalous days. The latest
+// day is still counting so it's never a drop. The trends of the days are
+// filled to chart.
+func detectAnomalies(metric string, chart *Chart) []Anomaly {
+	if len(chart.Data) == 0 {
+		return nil
+	}
+
+	var (
+		list  []Anomaly
+		today = timex.Truncate(time.Now())
+	)
+	chart.Trends = make([]int, len(chart.Data))
+	for i := minBaselineDays; i < len(chart.Data); i++ {
+		var baseline []float64
+		for j := i - 1; j >= 0 && len(baseline) < baselineDays; j-- {
+			if chart.Trends[j] == 0 {
+				baseline = append(baseline, chart.Data[j])
+			}
+		}
+		if len(baseline) < minBaselineDays {
+			continue
+		}
+
+		var (
+			mean, deviation = meanDeviation(baseline)
+			change          = chart.Data[i] - mean
+			// a count of events deviates at least the square root of its
+			// mean, which keeps a flat baseline from any change.
+			score = change / math.Max(deviation, math.Max(math.Sqrt(mean), 1))
+		)
+		switch {
+		case score >= anomalyScore && change >= minAnomalyChange:
+			chart.Trends[i] = TrendSpike
+		case score <= -anomalyScore && -change >= minAnomalyChange && i < len(chart.Data)-1:
+			chart.Trends[i] = TrendDrop
+		default:
+			continue
+		}
+
+		list = append(list, Anomaly{
+			Metric:   metric,
+			Date:     today.AddDate(0, 0, i-len(chart.Data)+1),
+			Count:    int(chart.Data[i]),
+			Baseline: mean,
+			Score:    score,
+		})
+	}
+	return list
+}
+
+// burstAnomalies turns the bursts highlighted in a daily chart of stars which
+// ends today into spikes, so that the star chart has a single detector.
+func burstAnomalies(chart *Chart) []Anomaly {
+	if len(chart.Data) == 0 {
+		return nil
+	}
+
+	var (
+		list        []Anomaly
+		today       = timex.Truncate(time.Now())
+		median, mad = burstBaseline(chart.Data)
+	)
+	chart.Trends = make([]int, len(chart.Data))
+	for i, e := range chart.Highlights {
+		if !e {
+			continue
+		}
+
+		chart.Trends[i] = TrendSpike
+		list = append(list, Anomaly{
+			Metric:   "stars",
+			Date:     today.AddDate(0, 0, i-len(chart.Data)+1),
+			Count:    int(chart.Data[i]),
+			Baseline: median,
+			Score:    (chart.Data[i] - median) / mad,
+		})
+	}
+	return list
+}
+
+func meanDeviation(list []float64) (float64, float64) {
+	var sum, squares float64
+	for _, e := range list {
+		sum += e
+	}
+	mean := sum / float64(len(list))
+	for _, e := range list {
+		squares += (e - mean) * (e - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(list)))
+}
+
+// hint names the release nearby a spike, it's empty if there isn't one.
+func (a Anomaly) hint(releases []PublishedRelease) string {
+	if a.Score < 0 {
+		return ""
+	}
+	for _, e := range releases {
+		if d := e.PublishedAt.Sub(a.Date); d > -releaseNearby && d < releaseNearby+timeDay {
+			return fmt.Sprintf("around release %s", e.Tag)
+		}
+	}
+	return ""
+}
+
+func (a Anomaly) String() string {
+	var text string
+	if a.Score > 0 {
+		text = fmt.Sprintf("+%d %s on %s (%.0f expected)", a.Count, a.Metric,
+			a.Date.Format("2006-01-02"), a.Baseline)
+	} else {
+		text = fmt.Sprintf("only %d %s on %s (%.0f expected)", a.Count, a.Metric,
+			a.Date.Format("2006-01-02"), a.Baseline)
+	}
+	if len(a.Hint) > 0 {
+		text += ", " + a.Hint
+	}
+	return text
+}
+
+// notableEvents detects the anomalies of the charts, the latest first. The
+// stars take the bursts which are highlighted in the star chart.
+func notableEvents(d *Data, releases []PublishedRelease) []Anomaly {
+	list := burstAnomalies(&d.LatestMonthStargazers)
+	for _, e := range []struct {
+		metric string
+		chart  *Chart
+	}{
+		{metric: "forks", chart: &d.LatestWeekForks},
+		{metric: "commits", chart: &d.LatestWeekCommits},
+		{metric: "issues", chart: &d.LatestWeekIssues},
+	} {
+		list = append(list, detectAnomalies(e.metric, e.chart)...)
+	}
+	for i := range list {
+		list[i].Hint = list[i].hint(releases)
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Date.After(list[j].Date)
+	})
+	return list
+}
+
+func formatAnomalies(list []Anomaly) string {
+	var ret []string
+	for _, e := range list {
+		ret = append(ret, e.String())
+	}
+	return strings.Join(ret, "\n")
+}
@@ -0,0 +1,104 @@
+// MIT License
+//
+// Copyright (c) 2022 anqiansong
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anqiansong/github-compare/pkg/timex"
+)
+
+func TestDetectAnomalies(t *testing.T) {
+	chart := Chart{Data: []float64{10, 12, 9, 11, 10, 80, 10, 11, 0, 9, 10}}
+	list := detectAnomalies("stars", &chart)
+	if len(list) != 2 {
+		t.Fatalf("unexpected anomalies: %+v", list)
+	}
+	if chart.Trends[5] != TrendSpike || chart.Trends[8] != TrendDrop || chart.Trends[10] != 0 {
+		t.Fatalf("unexpected trends: %v", chart.Trends)
+	}
+
+	today := timex.Truncate(time.Now())
+	if list[0].Count != 80 || list[0].Baseline != 10.4 || !list[0].Date.Equal(today.AddDate(0, 0, -5)) {
+		t.Fatalf("unexpected spike: %+v", list[0])
+	}
+	if list[1].Count != 0 || list[1].Score >= 0 {
+		t.Fatalf("unexpected drop: %+v", list[1])
+	}
+
+	// the latest day is still counting
+	chart = Chart{Data: []float64{20, 20, 20, 20, 0}}
+	if list := detectAnomalies("commits", &chart); len(list) != 0 {
+		t.Fatalf("unexpected anomalies: %+v", list)
+	}
+	// a quiet series is not anomalous
+	chart = Chart{Data: []float64{0, 0, 0, 0, 3, 0, 0}}
+	if list := detectAnomalies("forks", &chart); len(list) != 0 {
+		t.Fatalf("unexpected anomalies: %+v", list)
+	}
+}
+
+func TestAnomalyHint(t *testing.T) {
+	day := timex.Truncate(time.Now()).AddDate(0, 0, -3)
+	spike := Anomaly{Metric: "stars", Date: day, Count: 1200, Baseline: 40, Score: 12}
+	if h := spike.hint(nil); h != "" {
+		t.Fatalf("unexpected hint: %s", h)
+	}
+
+	releases := []PublishedRelease{{Tag: "v1.0.0", PublishedAt: day.Add(-30 * time.Hour)}}
+	spike.Hint = spike.hint(releases)
+	if expected := "+1200 stars on " + day.Format("2006-01-02") +
+		" (40 expected), around release v1.0.0"; spike.String() != expected {
+		t.Fatalf("unexpected event: %s", spike)
+	}
+	if h := (Anomaly{Metric: "commits", Date: day, Score: 4}).hint(nil); h != "" {
+		t.Fatalf("unexpected hint: %s", h)
+	}
+}
+
+func TestDataNotableEvents(t *testing.T) {
+	if d := NewData(Result{}, Windows{}); d.NotableEvents != "N/A" {
+		t.Fatalf("unexpected events: %s", d.NotableEvents)
+	}
+
+	now := time.Now()
+	var stars []time.Time
+	for i := 0; i < 50; i++ {
+		stars = append(stars, now.AddDate(0, 0, -3))
+	}
+	d := NewData(Result{Stargazers: stars}, Windows{})
+	if len(d.Anomalies) != 1 || d.Metrics.AnomalyCount != 1 || d.Anomalies[0].Count != 50 {
+		t.Fatalf("unexpected anomalies: %+v", d.Anomalies)
+	}
+	// the spikes of stars are the bursts of the star chart
+	chart := d.LatestMonthStargazers
+	for i, e := range chart.Highlights {
+		if e != (chart.Trends[i] == TrendSpike) {
+			t.Fatalf("the bursts %v disagree with the trends %v", chart.Highlights, chart.Trends)
+		}
+	}
+	if d := NewData(Result{Stargazers: []time.Time{}}, Windows{}); d.NotableEvents != "none" {
+		t.Fatalf("unexpected events: %s", d.NotableEvents)
+	}
+}
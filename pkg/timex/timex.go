@@ -22,7 +22,11 @@
 
 package timex
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
 
 func AllDays(start, end time.Time) []time.Time {
 	startZero := Truncate(start)
@@ -37,3 +41,25 @@ func AllDays(start, end time.Time) []time.Time {
 func Truncate(t time.Time) time.Time {
 	return t.Truncate(24 * time.Hour)
 }
+
+// ParseDuration supports the units of time.ParseDuration plus d(day), w(week)
+// and y(year).
+func ParseDuration(s string) (time.Duration, error) {
+	units := map[byte]time.Duration{
+		'd': 24 * time.Hour,
+		'w': 7 * 24 * time.Hour,
+		'y': 365 * 24 * time.Hour,
+	}
+
+	if len(s) > 1 {
+		if unit, ok := units[s[len(s)-1]]; ok {
+			n, err := strconv.ParseFloat(s[:len(s)-1], 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(n * float64(unit)), nil
+		}
+	}
+
+	return time.ParseDuration(s)
+}